@@ -0,0 +1,96 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+// Package names names the functions of package e for the analyzers.
+package names
+
+import "go/types"
+
+const (
+	// E is the import path of package e.
+	E = "github.com/fcavani/e"
+	// Safe is the import path of package e/safe.
+	Safe = "github.com/fcavani/e/safe"
+)
+
+// Template is a function that takes a template: the index of the template
+// and of its first argument, -1 if it takes no arguments.
+type Template struct {
+	Template int
+	Args     int
+}
+
+// Templates are the functions that take a template by their full name, see
+// Func.
+var Templates = map[string]Template{
+	E + ".New":            {0, 1},
+	E + ".NewN":           {0, 2},
+	E + ".Push":           {1, -1},
+	E + ".PushN":          {1, -1},
+	E + ".Error.Push":     {0, -1},
+	E + ".Capturer.New":   {0, 1},
+	E + ".Capturer.NewN":  {0, 2},
+	E + ".Capturer.Push":  {1, -1},
+	E + ".Capturer.PushN": {1, -1},
+	Safe + ".New":         {0, 1},
+	Safe + ".NewN":        {0, 2},
+	Safe + ".Newf":        {0, 1},
+	Safe + ".Push":        {1, -1},
+	Safe + ".PushN":       {1, -1},
+	Safe + ".Pushf":       {1, -1},
+	Safe + ".PushNf":      {1, -1},
+}
+
+// Func returns the full name of fn: the import path and the name of the
+// function, or the import path, the name of the receiver type and the
+// name of the method, separated by dots.
+func Func(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return ""
+	}
+	sig := fn.Type().(*types.Signature)
+	if recv := sig.Recv(); recv != nil {
+		t := recv.Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		named, ok := t.(*types.Named)
+		if !ok {
+			return ""
+		}
+		return fn.Pkg().Path() + "." + named.Obj().Name() + "." + fn.Name()
+	}
+	return fn.Pkg().Path() + "." + fn.Name()
+}
+
+// Short returns the name of fn as it is written by the callers, like
+// e.New or (*e.Error).Push.
+func Short(fn *types.Func) string {
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		t := recv.Type()
+		ptr := ""
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+			ptr = "*"
+		}
+		if named, ok := t.(*types.Named); ok {
+			return "(" + ptr + fn.Pkg().Name() + "." + named.Obj().Name() + ")." + fn.Name()
+		}
+	}
+	return fn.Pkg().Name() + "." + fn.Name()
+}
+
+// Package returns package e if pkg is e or imports it.
+func Package(pkg *types.Package) *types.Package {
+	if pkg.Path() == E {
+		return pkg
+	}
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == E {
+			return imp
+		}
+	}
+	return nil
+}
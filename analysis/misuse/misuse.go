@@ -0,0 +1,316 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+// Package misuse defines an analyzer that reports the misuses of package e:
+//
+//   - values passed to the interface{} parameters of e that make it panic,
+//     like an int where an error or a string is expected;
+//   - the ignored results of Push and Forward, that don't modify the chain;
+//   - *e.Error compared with == or !=, the chains are compared by pointer;
+//   - the constants passed to FindStr that aren't in any known template.
+//
+// The known templates are the constant templates passed to the functions
+// of e, to errors.New and to fmt.Errorf and the constants of string types
+// that are errors, like e.Kind, in the package and in its dependencies. The
+// messages of other errors, like syscall.Errno, aren't known.
+package misuse
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/fcavani/e/analysis/internal/names"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `report the misuses of package e
+
+Reports the arguments that make the functions of e panic, the ignored
+results of Push and Forward, the comparisons of *e.Error with == and the
+constants passed to FindStr that aren't in any known error template.`
+
+// Analyzer reports the misuses of package e.
+var Analyzer = &analysis.Analyzer{
+	Name:      "emisuse",
+	Doc:       doc,
+	URL:       "https://pkg.go.dev/github.com/fcavani/e/analysis/misuse",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(templates)},
+}
+
+// templates is the fact with the known templates of a package.
+type templates struct {
+	List []string
+}
+
+func (*templates) AFact() {}
+
+func (t *templates) String() string {
+	return "templates(" + strings.Join(t.List, ", ") + ")"
+}
+
+// accept is the set of types accepted by a parameter.
+type accept uint8
+
+const (
+	acceptError accept = 1 << iota
+	acceptString
+	acceptStringer
+	// acceptPointer accepts only *e.Error.
+	acceptPointer
+)
+
+func (a accept) String() string {
+	var s []string
+	if a&acceptPointer != 0 {
+		s = append(s, "an *e.Error")
+	}
+	if a&acceptError != 0 {
+		s = append(s, "an error")
+	}
+	if a&acceptStringer != 0 {
+		s = append(s, "a fmt.Stringer")
+	}
+	if a&acceptString != 0 {
+		s = append(s, "a string")
+	}
+	if len(s) == 1 {
+		return s[0]
+	}
+	return strings.Join(s[:len(s)-1], ", ") + " or " + s[len(s)-1]
+}
+
+const errorOrString = acceptError | acceptString
+
+// params are the interface{} parameters of the functions of e and the
+// types that they accept.
+var params = map[string][]accept{
+	names.E + ".New":               {errorOrString},
+	names.E + ".NewN":              {errorOrString},
+	names.E + ".Push":              {errorOrString, errorOrString},
+	names.E + ".PushN":             {errorOrString, errorOrString},
+	names.E + ".Forward":           {errorOrString},
+	names.E + ".ForwardN":          {errorOrString},
+	names.E + ".Equal":             {acceptError, errorOrString},
+	names.E + ".Find":              {acceptError, errorOrString},
+	names.E + ".Trace":             {acceptError},
+	names.E + ".Contains":          {errorOrString},
+	names.E + ".FindStr":           {acceptPointer},
+	names.E + ".Merge":             {errorOrString, errorOrString},
+	names.E + ".Human":             {errorOrString},
+	names.E + ".Copy":              {acceptError},
+	names.E + ".Phrase":            {errorOrString | acceptStringer},
+	names.E + ".String":            {errorOrString | acceptStringer},
+	names.E + ".Error.Push":        {errorOrString},
+	names.E + ".Capturer.New":      {errorOrString},
+	names.E + ".Capturer.NewN":     {errorOrString},
+	names.E + ".Capturer.Push":     {errorOrString, errorOrString},
+	names.E + ".Capturer.PushN":    {errorOrString, errorOrString},
+	names.E + ".Capturer.Forward":  {errorOrString},
+	names.E + ".Capturer.ForwardN": {errorOrString},
+	names.E + ".Capturer.Merge":    {errorOrString, errorOrString},
+	names.E + ".Error.Equal":       {errorOrString},
+	names.E + ".Error.Find":        {errorOrString},
+}
+
+// pure are the functions whose result must be used.
+var pure = map[string]bool{
+	names.E + ".Push":              true,
+	names.E + ".PushN":             true,
+	names.E + ".Forward":           true,
+	names.E + ".ForwardN":          true,
+	names.E + ".Error.Push":        true,
+	names.E + ".Error.Forward":     true,
+	names.E + ".Capturer.Push":     true,
+	names.E + ".Capturer.PushN":    true,
+	names.E + ".Capturer.Forward":  true,
+	names.E + ".Capturer.ForwardN": true,
+	names.Safe + ".Push":           true,
+	names.Safe + ".PushN":          true,
+	names.Safe + ".Pushf":          true,
+	names.Safe + ".PushNf":         true,
+	names.Safe + ".Forward":        true,
+}
+
+// findStr are the functions that find a string in the templates of the
+// chain and the index of the string.
+var findStr = map[string]int{
+	names.E + ".FindStr":       1,
+	names.E + ".Error.FindStr": 0,
+	names.Safe + ".FindStr":    1,
+}
+
+var (
+	errorType    = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	stringerType = types.NewInterfaceType([]*types.Func{
+		types.NewFunc(token.NoPos, nil, "String", types.NewSignatureType(nil, nil, nil, nil,
+			types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.String])), false)),
+	}, nil).Complete()
+)
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	known := collect(pass, inspect)
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil), (*ast.ExprStmt)(nil), (*ast.BinaryExpr)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			checkCall(pass, n, known)
+		case *ast.ExprStmt:
+			call, ok := ast.Unparen(n.X).(*ast.CallExpr)
+			if !ok {
+				return
+			}
+			fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+			if ok && pure[names.Func(fn)] {
+				pass.Reportf(call.Pos(), "result of %s is ignored, it returns a new chain", names.Short(fn))
+			}
+		case *ast.BinaryExpr:
+			if n.Op != token.EQL && n.Op != token.NEQ {
+				return
+			}
+			if isNil(pass, n.X) || isNil(pass, n.Y) {
+				return
+			}
+			if isErrorPointer(pass.TypesInfo.TypeOf(n.X)) || isErrorPointer(pass.TypesInfo.TypeOf(n.Y)) {
+				pass.Reportf(n.OpPos, "*e.Error compared with %s, use errors.Is or Equal", n.Op)
+			}
+		}
+	})
+	return nil, nil
+}
+
+func checkCall(pass *analysis.Pass, call *ast.CallExpr, known []string) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return
+	}
+	name := names.Func(fn)
+	for i, a := range params[name] {
+		if i >= len(call.Args) {
+			break
+		}
+		t := pass.TypesInfo.TypeOf(call.Args[i])
+		if t != nil && !accepts(t, a) {
+			pass.Reportf(call.Args[i].Pos(), "%s panics with %s, it must be %v", names.Short(fn), t, a)
+		}
+	}
+	i, found := findStr[name]
+	if !found || i >= len(call.Args) {
+		return
+	}
+	tv := pass.TypesInfo.Types[call.Args[i]]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+	sub := constant.StringVal(tv.Value)
+	if sub == "" {
+		return
+	}
+	for _, tmpl := range known {
+		if strings.Contains(tmpl, sub) {
+			return
+		}
+	}
+	pass.Reportf(call.Args[i].Pos(), "%s: %q isn't in any known error template", names.Short(fn), sub)
+}
+
+// accepts reports whether a value of type t doesn't make a parameter that
+// accepts a panic. The values of interface types may hold anything, they
+// aren't reported.
+func accepts(t types.Type, a accept) bool {
+	if types.IsInterface(t) {
+		return true
+	}
+	if b, ok := t.(*types.Basic); ok && b.Kind() == types.UntypedNil {
+		return true
+	}
+	if a&acceptPointer != 0 && isErrorPointer(t) {
+		return true
+	}
+	if a&acceptError != 0 && types.Implements(t, errorType) {
+		return true
+	}
+	if a&acceptStringer != 0 && types.Implements(t, stringerType) {
+		return true
+	}
+	if b, ok := t.(*types.Basic); ok && a&acceptString != 0 {
+		return b.Kind() == types.String || b.Kind() == types.UntypedString
+	}
+	return false
+}
+
+func isErrorPointer(t types.Type) bool {
+	p, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := p.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == names.E && obj.Name() == "Error"
+}
+
+func isNil(pass *analysis.Pass, x ast.Expr) bool {
+	return pass.TypesInfo.Types[x].IsNil()
+}
+
+// collect returns the templates known by the package and exports the
+// templates of the package as a fact.
+func collect(pass *analysis.Pass, inspect *inspector.Inspector) []string {
+	set := make(map[string]bool)
+	add := func(x ast.Expr) {
+		tv := pass.TypesInfo.Types[x]
+		if tv.Value != nil && tv.Value.Kind() == constant.String {
+			set[constant.StringVal(tv.Value)] = true
+		}
+	}
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok {
+			return
+		}
+		name := names.Func(fn)
+		if t, found := names.Templates[name]; found && t.Template < len(call.Args) {
+			add(call.Args[t.Template])
+		}
+		switch name {
+		case "errors.New", "fmt.Errorf", names.E + ".Merge", names.E + ".Capturer.Merge":
+			for _, arg := range call.Args {
+				add(arg)
+			}
+		}
+	})
+	for _, tv := range pass.TypesInfo.Types {
+		if tv.Value != nil && tv.Value.Kind() == constant.String && tv.Type != nil && types.Implements(tv.Type, errorType) {
+			set[constant.StringVal(tv.Value)] = true
+		}
+	}
+	local := make([]string, 0, len(set))
+	for tmpl := range set {
+		local = append(local, tmpl)
+	}
+	sort.Strings(local)
+	if len(local) > 0 {
+		pass.ExportPackageFact(&templates{List: local})
+	}
+	known := local
+	for _, f := range pass.AllPackageFacts() {
+		if t, ok := f.Fact.(*templates); ok && f.Package != pass.Pkg {
+			known = append(known, t.List...)
+		}
+	}
+	return known
+}
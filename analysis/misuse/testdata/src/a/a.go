@@ -0,0 +1,82 @@
+package a // want package:`templates\(captured message, foo, message, pushed, user not found, wrapped: %w\)`
+
+import (
+	"fmt"
+	"io"
+
+	"b"
+
+	"github.com/fcavani/e"
+)
+
+type name string
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+type valueErr struct{}
+
+func (*valueErr) Error() string { return "value" }
+
+func f(err error, ee *e.Error, v interface{}) {
+	_ = e.New(io.EOF)
+	_ = e.New("message")
+	_ = e.New(v)
+	_ = e.New(ee)
+	_ = e.New(nil)
+	_ = e.New(42)          // want `e.New panics with int, it must be an error or a string`
+	_ = e.New(name("foo")) // want `e.New panics with a.name, it must be an error or a string`
+	_ = e.New(valueErr{})  // want `e.New panics with a.valueErr`
+	_ = e.New(&valueErr{})
+	_ = e.Push(err, 1.5)    // want `e.Push panics with float64`
+	_ = e.Equal("foo", err) // want `e.Equal panics with string, it must be an error`
+	_ = e.Find(err, "foo")
+	_ = e.FindStr(err, "closed")
+	_ = e.FindStr(io.EOF, "EOF")
+	_ = e.FindStr(&valueErr{}, "value") // want `e.FindStr panics with \*a.valueErr, it must be an \*e.Error`
+	_ = e.Copy("foo")                   // want `e.Copy panics with string, it must be an error`
+	_ = e.Phrase(stringer{})
+	_ = e.Phrase(1) // want `e.Phrase panics with int, it must be an error, a fmt.Stringer or a string`
+	_ = ee.Equal(3) // want `\(\*e.Error\).Equal panics with int`
+
+	e.Push(err, "pushed") // want `result of e.Push is ignored, it returns a new chain`
+	ee.Push("pushed")     // want `result of \(\*e.Error\).Push is ignored`
+	e.Forward(err)        // want `result of e.Forward is ignored`
+	(e.ForwardN(err, 1))  // want `result of e.ForwardN is ignored`
+	ee.Forward()          // want `result of \(\*e.Error\).Forward is ignored`
+	c := e.With(e.Config{})
+	_ = c.New(42)       // want `\(\*e.Capturer\).New panics with int`
+	_ = c.NewN(1.5, 0)  // want `\(\*e.Capturer\).NewN panics with float64`
+	_ = c.Merge(err, 3) // want `\(\*e.Capturer\).Merge panics with int`
+	_ = c.Push(err, "pushed")
+	c.Push(err, "pushed")     // want `result of \(\*e.Capturer\).Push is ignored`
+	c.PushN(err, "pushed", 1) // want `result of \(\*e.Capturer\).PushN is ignored`
+	c.Forward(err)            // want `result of \(\*e.Capturer\).Forward is ignored`
+	c.ForwardN(err, 1)        // want `result of \(\*e.Capturer\).ForwardN is ignored`
+	_ = c.New("captured message")
+	ee = ee.Push("pushed")
+
+	if ee == nil || nil != ee {
+		return
+	}
+	other := &e.Error{}
+	_ = ee == other // want `\*e.Error compared with ==, use errors.Is or Equal`
+	_ = err != ee   // want `\*e.Error compared with !=`
+	_ = err == io.EOF
+
+	_ = ee.FindStr("message")
+	_ = ee.FindStr("captured")
+	_ = ee.FindStr("not found")
+	_ = ee.FindStr("bad id")
+	_ = e.FindStr(ee, "EOF")
+	_ = e.FindStr(ee, "type is")
+	_ = e.FindStr(ee, "wrapped")
+	_ = e.FindStr(ee, "closed")
+	_ = e.FindStr(ee, "missing") // want `e.FindStr: "missing" isn't in any known error template`
+	_ = ee.FindStr("nowhere")    // want `\(\*e.Error\).FindStr: "nowhere" isn't in any known error template`
+	_ = ee.FindStr(fmt.Sprint(v))
+	_ = b.Fail
+	_ = b.ErrNotFound
+	_ = fmt.Errorf("wrapped: %w", err)
+}
@@ -0,0 +1,16 @@
+// Package b has templates used by package a.
+package b
+
+import (
+	"errors"
+
+	"github.com/fcavani/e"
+)
+
+const ErrNotFound e.Kind = "user not found"
+
+var ErrClosed = errors.New("connection closed")
+
+func Fail(id int) error {
+	return e.New("bad id %v", id)
+}
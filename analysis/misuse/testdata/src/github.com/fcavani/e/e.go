@@ -0,0 +1,52 @@
+// Package e is a stub of package e for the tests of the analyzer.
+package e
+
+type Option interface {
+	apply(e *Error)
+}
+
+type Kind string
+
+func (k Kind) Error() string  { return string(k) }
+func (k Kind) apply(e *Error) {}
+
+const ErrInvalidType Kind = "type is invalid"
+
+type Error struct{}
+
+func (e *Error) Error() string                              { return "" }
+func (e *Error) Push(ie interface{}, opts ...Option) *Error { return e }
+func (e *Error) Forward() *Error                            { return e }
+func (e *Error) Equal(ie interface{}) bool                  { return false }
+func (e *Error) Find(ie interface{}) int                    { return -1 }
+func (e *Error) FindStr(sub string) int                     { return -1 }
+
+type Config struct{}
+
+type Capturer struct{}
+
+func With(cfg Config) *Capturer { return &Capturer{} }
+
+func (c *Capturer) New(ie interface{}, a ...interface{}) error            { return nil }
+func (c *Capturer) NewN(ie interface{}, n int, a ...interface{}) error    { return nil }
+func (c *Capturer) Push(e1, e2 interface{}, opts ...Option) error         { return nil }
+func (c *Capturer) PushN(e1, e2 interface{}, n int, opts ...Option) error { return nil }
+func (c *Capturer) Forward(ie interface{}) error                          { return nil }
+func (c *Capturer) ForwardN(ie interface{}, n int) error                  { return nil }
+func (c *Capturer) Merge(e1, e2 interface{}) error                        { return nil }
+
+func New(ie interface{}, a ...interface{}) error            { return nil }
+func NewN(ie interface{}, n int, a ...interface{}) error    { return nil }
+func Push(e1, e2 interface{}, opts ...Option) error         { return nil }
+func PushN(e1, e2 interface{}, n int, opts ...Option) error { return nil }
+func Forward(ie interface{}) error                          { return nil }
+func ForwardN(ie interface{}, n int) error                  { return nil }
+func Equal(l, r interface{}) bool                           { return false }
+func Find(e, ie interface{}) int                            { return -1 }
+func Trace(ie interface{}) string                           { return "" }
+func Contains(ie interface{}, sub string) bool              { return false }
+func FindStr(ie interface{}, sub string) int                { return -1 }
+func Merge(e1, e2 interface{}) error                        { return nil }
+func Human(err interface{}) string                          { return "" }
+func Copy(ie interface{}) error                             { return nil }
+func Phrase(i interface{}) string                           { return "" }
@@ -0,0 +1,110 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+// Package printf defines an analyzer that checks the templates passed to
+// the functions of package e like the printf check of go vet does for
+// fmt.Printf. New and NewN are checked as printf-like functions, the
+// options among the arguments aren't counted, except the kinds that have a
+// verb left for them, like New does. Push and PushN don't take
+// arguments, a template pushed with verbs is reported.
+package printf
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"github.com/fcavani/e/analysis/internal/names"
+	"github.com/fcavani/e/internal/format"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `check the templates of the errors of package e
+
+The calls to New, NewN, Push and PushN of the packages e and e/safe and of
+*e.Capturer, and to the method Push of *e.Error, are checked for bad verbs and for verbs that
+don't match the number of arguments.`
+
+// Analyzer checks the templates of package e.
+var Analyzer = &analysis.Analyzer{
+	Name:     "eprintf",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/github.com/fcavani/e/analysis/printf",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// optionType returns the interface e.Option if pkg is e or imports it.
+func optionType(pkg *types.Package) *types.Interface {
+	pkg = names.Package(pkg)
+	if pkg == nil {
+		return nil
+	}
+	obj := pkg.Scope().Lookup("Option")
+	if obj == nil {
+		return nil
+	}
+	iface, _ := obj.Type().Underlying().(*types.Interface)
+	return iface
+}
+
+// kindType returns the type e.Kind if pkg is e or imports it.
+func kindType(pkg *types.Package) types.Type {
+	pkg = names.Package(pkg)
+	if pkg == nil {
+		return nil
+	}
+	obj := pkg.Scope().Lookup("Kind")
+	if obj == nil {
+		return nil
+	}
+	return obj.Type()
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok {
+			return
+		}
+		name := names.Func(fn)
+		f, found := names.Templates[name]
+		if !found || f.Template >= len(call.Args) {
+			return
+		}
+		tv := pass.TypesInfo.Types[call.Args[f.Template]]
+		if tv.Value == nil || tv.Value.Kind() != constant.String {
+			return
+		}
+		template := constant.StringVal(tv.Value)
+		nargs := 0
+		if f.Args >= 0 {
+			if call.Ellipsis.IsValid() {
+				return
+			}
+			option := optionType(fn.Pkg())
+			kind := kindType(fn.Pkg())
+			verbs := format.Count(template)
+			for _, arg := range call.Args[f.Args:] {
+				t := pass.TypesInfo.TypeOf(arg)
+				isKind := kind != nil && t != nil && types.Identical(t, kind)
+				if option != nil && t != nil && types.Implements(t, option) && (!isKind || nargs >= verbs) {
+					continue
+				}
+				nargs++
+			}
+		}
+		err := format.Check(template, nargs)
+		if err != nil {
+			pass.Reportf(call.Args[f.Template].Pos(), "%s: %v", names.Short(fn), err)
+		}
+	})
+	return nil, nil
+}
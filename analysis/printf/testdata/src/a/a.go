@@ -0,0 +1,47 @@
+package a
+
+import (
+	"github.com/fcavani/e"
+	"github.com/fcavani/e/safe"
+)
+
+const ErrKind e.Kind = "kind %v"
+
+const template = "value %v"
+
+func f(err error, args []interface{}, v interface{}) {
+	_ = e.New("no verbs")
+	_ = e.New("value %v and %d", 1, 2)
+	_ = e.New("value %v", 1, ErrKind, e.Field{Key: "k", Value: 2})
+	_ = e.New(template, 1)
+	_ = e.New("value %v", args...)
+	_ = e.New(err, 1)
+	_ = e.New("value %v", v)
+	_ = e.New("%[2]v %[1]v", 1, 2)
+	_ = e.New("100%%")
+	_ = e.New(ErrKind, 1)
+	_ = e.New("value %v", ErrKind)
+	_ = e.New("value %v: %v", 1, ErrKind, ErrKind)
+	_ = e.New("value %v", 1, 2, ErrKind) // want `e.New: "value %v" uses 1 of the 2 arguments`
+
+	_ = e.New("value %v")             // want `e.New: missing argument for %v in "value %v"`
+	_ = e.New("value %v", 1, 2)       // want `e.New: "value %v" uses 1 of the 2 arguments`
+	_ = e.New("value %z", 1)          // want `e.New: bad verb %z`
+	_ = e.New(template)               // want `e.New: missing argument`
+	_ = e.New(ErrKind)                // want `e.New: missing argument`
+	_ = e.NewN("value %v %v", 1, "a") // want `e.NewN: missing argument`
+	_ = e.NewN("value %v", 1, "a")
+	_ = e.Push(err, "pushed %v")     // want `e.Push: missing argument`
+	_ = e.PushN(err, "pushed %v", 1) // want `e.PushN: missing argument`
+	_ = e.Push(err, "pushed")
+	_ = (&e.Error{}).Push("pushed %d")         // want `\(\*e.Error\).Push: missing argument`
+	_ = e.With(e.Config{}).New("value %v")     // want `\(\*e.Capturer\).New: missing argument`
+	_ = e.With(e.Config{}).NewN("value %v", 0) // want `\(\*e.Capturer\).NewN: missing argument`
+	_ = e.With(e.Config{}).New("value %v", 1, ErrKind)
+	_ = e.With(e.Config{}).Push(err, "pushed %v")     // want `\(\*e.Capturer\).Push: missing argument`
+	_ = e.With(e.Config{}).PushN(err, "pushed %v", 1) // want `\(\*e.Capturer\).PushN: missing argument`
+	_ = safe.Newf("value %v")                         // want `safe.Newf: missing argument`
+	_ = safe.Newf("value %v", 1)
+	_ = safe.New(ErrKind, 1, 2) // want `safe.New: "kind %v" uses 1 of the 2 arguments`
+	_ = safe.Push(err, ErrKind) // want `safe.Push: missing argument`
+}
@@ -0,0 +1,39 @@
+// Package e is a stub of package e for the tests of the analyzer.
+package e
+
+type Option interface {
+	apply(e *Error)
+}
+
+type Kind string
+
+func (k Kind) Error() string  { return string(k) }
+func (k Kind) apply(e *Error) {}
+
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func (f Field) apply(e *Error) {}
+
+type Error struct{}
+
+func (e *Error) Error() string                              { return "" }
+func (e *Error) Push(ie interface{}, opts ...Option) *Error { return e }
+
+type Config struct{}
+
+type Capturer struct{}
+
+func With(cfg Config) *Capturer { return &Capturer{} }
+
+func (c *Capturer) New(ie interface{}, a ...interface{}) error            { return nil }
+func (c *Capturer) NewN(ie interface{}, n int, a ...interface{}) error    { return nil }
+func (c *Capturer) Push(e1, e2 interface{}, opts ...Option) error         { return nil }
+func (c *Capturer) PushN(e1, e2 interface{}, n int, opts ...Option) error { return nil }
+
+func New(ie interface{}, a ...interface{}) error            { return nil }
+func NewN(ie interface{}, n int, a ...interface{}) error    { return nil }
+func Push(e1, e2 interface{}, opts ...Option) error         { return nil }
+func PushN(e1, e2 interface{}, n int, opts ...Option) error { return nil }
@@ -0,0 +1,12 @@
+// Package safe is a stub of package safe for the tests of the analyzer.
+package safe
+
+import "github.com/fcavani/e"
+
+type Text interface {
+	~string
+}
+
+func New(err error, a ...interface{}) error         { return nil }
+func Newf[T Text](format T, a ...interface{}) error { return nil }
+func Push(e1, e2 error, opts ...e.Option) error     { return nil }
@@ -0,0 +1,125 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// Class tells if the operation that failed is worth retrying. A Class is
+// an option, passed to New or Push it marks the new error.
+type Class uint8
+
+const (
+	// Unclassified is the class of the errors that aren't marked.
+	Unclassified Class = iota
+	// Retryable marks an error of an operation that can be repeated.
+	Retryable
+	// Temporary marks an error caused by a transient condition, the
+	// operation may succeed later.
+	Temporary
+	// Permanent marks an error that repeating the operation won't fix.
+	Permanent
+)
+
+var classNames = []string{"", "retryable", "temporary", "permanent"}
+
+func (c Class) String() string {
+	if int(c) < len(classNames) {
+		return classNames[c]
+	}
+	return fmt.Sprintf("Class(%d)", c)
+}
+
+func parseClass(s string) (Class, error) {
+	for i, name := range classNames {
+		if name == s {
+			return Class(i), nil
+		}
+	}
+	return Unclassified, fmt.Errorf("invalid class %q", s)
+}
+
+func (c Class) apply(e *Error) {
+	e.class = c
+}
+
+// Class returns the class of this error of the chain, use ClassOf to
+// classify the chain.
+func (e *Error) Class() Class {
+	return e.class
+}
+
+// retryErrnos are the system errors that are classified as temporary.
+var retryErrnos = map[syscall.Errno]bool{
+	syscall.EAGAIN:       true,
+	syscall.EINTR:        true,
+	syscall.EBUSY:        true,
+	syscall.ECONNRESET:   true,
+	syscall.ECONNABORTED: true,
+	syscall.ECONNREFUSED: true,
+	syscall.ETIMEDOUT:    true,
+}
+
+// classifyError classifies the errors that aren't *Error: the timeouts of
+// net.Error and some syscall.Errno, like EAGAIN and ECONNRESET, are
+// temporary.
+func classifyError(err error) Class {
+	var errno syscall.Errno
+	if errors.As(err, &errno) && retryErrnos[errno] {
+		return Temporary
+	}
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return Temporary
+	}
+	return Unclassified
+}
+
+// ClassOf returns the first class found in the chain of err, including the
+// merged errors. The errors wrapped by the chain are classified too, see
+// IsRetryable.
+func ClassOf(err error) Class {
+	if err == nil {
+		return Unclassified
+	}
+	class := Unclassified
+	var e *Error
+	if errors.As(err, &e) {
+		e.walk(0, func(err *Error, deep int) bool {
+			class = err.class
+			if class == Unclassified {
+				switch err.err.(type) {
+				case *Error, causes:
+				default:
+					class = classifyError(err.err)
+				}
+			}
+			return class == Unclassified
+		})
+		if class != Unclassified {
+			return class
+		}
+	}
+	return classifyError(err)
+}
+
+// IsRetryable returns true if the class of err is Retryable or Temporary.
+// The timeouts of net.Error and the syscall.Errno EAGAIN (EWOULDBLOCK),
+// EINTR, EBUSY, ECONNRESET, ECONNABORTED, ECONNREFUSED and ETIMEDOUT are
+// temporary if no error above them in the chain is marked.
+func IsRetryable(err error) bool {
+	class := ClassOf(err)
+	return class == Retryable || class == Temporary
+}
+
+// IsPermanent returns true if the class of err is Permanent.
+func IsPermanent(err error) bool {
+	return ClassOf(err) == Permanent
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestClass(t *testing.T) {
+	err := New(ErrDummy, Retryable).(*Error)
+	if err.Class() != Retryable || !IsRetryable(err) || IsPermanent(err) {
+		t.Fatal("wrong class", err.Class())
+	}
+	if IsRetryable(New(ErrDummy)) || ClassOf(nil) != Unclassified {
+		t.Fatal("unclassified error is retryable")
+	}
+	perm := err.Push(ErrStr, Permanent)
+	if ClassOf(perm) != Permanent || IsRetryable(perm) || err.Class() != Retryable {
+		t.Fatal("wrong class after push")
+	}
+	if ClassOf(perm.Push(ErrSilly)) != Permanent {
+		t.Fatal("class not found in the chain")
+	}
+	if ClassOf(Push(ErrDummy, New(ErrStr).(*Error), Temporary)) != Temporary {
+		t.Fatal("Push didn't apply the options")
+	}
+	if ClassOf(Push(nil, ErrStr, Permanent)) != Permanent {
+		t.Fatal("Push didn't apply the options")
+	}
+	if ClassOf(Forward(perm)) != Permanent || ClassOf(Copy(perm)) != Permanent {
+		t.Fatal("class lost")
+	}
+	if ClassOf(Merge(ErrDummy, perm)) != Permanent {
+		t.Fatal("class of merged error not found")
+	}
+	if ClassOf(fmt.Errorf("wrapped: %w", err)) != Retryable {
+		t.Fatal("class of wrapped error not found")
+	}
+	if Retryable.String() != "retryable" || Class(42).String() != "Class(42)" {
+		t.Fatal("wrong string")
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestClassSystemErrors(t *testing.T) {
+	for _, err := range []error{
+		syscall.EAGAIN,
+		syscall.ECONNRESET,
+		&os.SyscallError{Syscall: "read", Err: syscall.ECONNRESET},
+		timeoutError{},
+		New(syscall.ETIMEDOUT),
+		New(ErrDummy).(*Error).Push(syscall.EINTR).Push(ErrStr),
+		fmt.Errorf("wrapped: %w", syscall.EAGAIN),
+	} {
+		if !IsRetryable(err) || ClassOf(err) != Temporary {
+			t.Fatal("not retryable", err)
+		}
+	}
+	for _, err := range []error{syscall.ENOENT, New(ErrDummy), &net.AddrError{Err: "bad"}} {
+		if IsRetryable(err) {
+			t.Fatal("retryable", err)
+		}
+	}
+	if IsRetryable(New(syscall.ECONNRESET).(*Error).Push(ErrDummy, Permanent)) {
+		t.Fatal("the mark didn't win")
+	}
+	if !IsRetryable(gobRoundTrip(t, New(syscall.ECONNRESET).(*Error))) {
+		t.Fatal("errno lost")
+	}
+}
+
+func TestClassCodecs(t *testing.T) {
+	err := New(ErrDummy, Permanent).(*Error).Push(ErrStr, Temporary)
+	for name, rt := range map[string]func(*testing.T, *Error) *Error{"gob": gobRoundTrip, "msgpack": msgpackRoundTrip} {
+		derr := rt(t, err)
+		if derr.Class() != Temporary || derr.next.Class() != Permanent {
+			t.Fatal(name, "wrong class")
+		}
+	}
+	b, er := json.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var jerr *Error
+	if er := json.Unmarshal(b, &jerr); er != nil {
+		t.Fatal(er)
+	}
+	if jerr.Class() != Temporary || jerr.next.Class() != Permanent {
+		t.Fatal("json: wrong class")
+	}
+	if er := json.Unmarshal([]byte(`{"message":"x","template":"x","class":"bogus"}`), &jerr); er == nil {
+		t.Fatal("invalid class accepted")
+	}
+}
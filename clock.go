@@ -0,0 +1,72 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// start is the origin of the monotonic clock of the errors.
+var start = time.Now()
+
+// goroutineID returns the ID of the current goroutine, read from the header
+// of its stack, or zero.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// Time returns when the error was created, zero if it wasn't recorded, see
+// Config. For the errors created in this process the time has a monotonic
+// clock reading too, the errors decoded only have the wall clock.
+func (e *Error) Time() time.Time {
+	return e.time
+}
+
+// Monotonic returns the time elapsed since the start of the process that
+// created the error until it was created, zero if it wasn't recorded. Only
+// the readings of the same process can be compared.
+func (e *Error) Monotonic() time.Duration {
+	return e.mono
+}
+
+// Goroutine returns the ID of the goroutine that created the error, zero if
+// it wasn't recorded, see Config.
+func (e *Error) Goroutine() uint64 {
+	return e.goroutine
+}
+
+// formatDelta renders the time elapsed between the error after e in the
+// chain and e, like " +12ms", or an empty string if one of them hasn't the
+// time. The monotonic clock is used if both have it.
+func (e *Error) formatDelta() string {
+	if e.next == nil || e.time.IsZero() || e.next.time.IsZero() {
+		return ""
+	}
+	d := e.time.Sub(e.next.time)
+	switch abs := max(d, -d); {
+	case abs >= time.Millisecond:
+		d = d.Round(time.Millisecond)
+	case abs >= time.Microsecond:
+		d = d.Round(time.Microsecond)
+	}
+	if d < 0 {
+		return " " + d.String()
+	}
+	return " +" + d.String()
+}
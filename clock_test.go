@@ -0,0 +1,78 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	if err := New(ErrDummy).(*Error); !err.Time().IsZero() || err.Monotonic() != 0 || err.Goroutine() != 0 {
+		t.Fatal("time recorded")
+	}
+	c := With(Config{Time: true, Goroutine: true})
+	before := time.Now()
+	err := c.New(ErrDummy).(*Error)
+	if err.Time().Before(before) || err.Monotonic() <= 0 || err.Goroutine() == 0 {
+		t.Fatal("time not recorded", err.Time(), err.Monotonic(), err.Goroutine())
+	}
+	ch := make(chan uint64)
+	go func() {
+		ch <- c.New(ErrDummy).(*Error).Goroutine()
+	}()
+	if id := <-ch; id == 0 || id == err.Goroutine() {
+		t.Fatal("wrong goroutine", id)
+	}
+	if cp := Copy(err).(*Error); !cp.Time().Equal(err.Time()) || cp.Goroutine() != err.Goroutine() {
+		t.Fatal("copy lost the time")
+	}
+}
+
+func TestClockTrace(t *testing.T) {
+	base := time.Now()
+	err := &Error{err: ErrDummy, time: base, next: &Error{err: ErrSilly, time: base.Add(-12345 * time.Microsecond)}}
+	err.next.next = &Error{err: GoError("third")}
+	if trace := err.Trace(); trace != ErrDummy.Error()+" +12ms\n"+ErrSilly.Error()+"\nthird\n" {
+		t.Fatal("wrong trace", trace)
+	}
+	err.next.time = base.Add(1500 * time.Nanosecond)
+	if trace := err.Trace(); !strings.HasPrefix(trace, ErrDummy.Error()+" -2µs\n") {
+		t.Fatal("wrong trace", trace)
+	}
+	c := With(Config{Time: true})
+	chain := c.Forward(c.New(ErrDummy)).(*Error)
+	if !strings.Contains(chain.Trace(), " +") {
+		t.Fatal("no delta", chain.Trace())
+	}
+}
+
+func TestClockCodecs(t *testing.T) {
+	err := With(Config{Time: true, Goroutine: true}).New(ErrDummy).(*Error)
+	b, er := json.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var jerr *Error
+	if er := json.Unmarshal(b, &jerr); er != nil {
+		t.Fatal(er)
+	}
+	derrs := map[string]*Error{"gob": gobRoundTrip(t, err), "msgpack": msgpackRoundTrip(t, err), "json": jerr}
+	for name, derr := range derrs {
+		if !derr.Time().Equal(err.Time()) || derr.Monotonic() != err.Monotonic() || derr.Goroutine() != err.Goroutine() {
+			t.Fatal(name, "wrong time", derr.Time(), derr.Monotonic(), derr.Goroutine())
+		}
+	}
+	buf := bytes.NewBuffer([]byte{})
+	slog.New(slog.NewJSONHandler(buf, nil)).Info("failed", "err", err)
+	if !strings.Contains(buf.String(), `"goroutine":`) || !strings.Contains(buf.String(), `"time":"`+err.Time().Format(time.RFC3339Nano)) {
+		t.Fatal("wrong log", buf.String())
+	}
+}
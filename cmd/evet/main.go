@@ -0,0 +1,23 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+// Command evet runs the analyzers of package e. It is a vet tool:
+//
+//	go install github.com/fcavani/e/cmd/evet
+//	go vet -vettool=$(which evet) ./...
+package main
+
+import (
+	"github.com/fcavani/e/analysis/misuse"
+	"github.com/fcavani/e/analysis/printf"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(
+		misuse.Analyzer,
+		printf.Analyzer,
+	)
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
+)
+
+// Config sets how the errors are created.
+type Config struct {
+	// Callers captures the stack where the errors are created, the debug
+	// information.
+	Callers bool
+	// Depth is the maximum number of frames captured, zero is 32.
+	Depth int
+	// Path sets how the paths of the source files are rendered in the
+	// messages, in the stack and in the codecs.
+	Path PathMode
+	// TrimPrefix are the prefixes removed from the paths by PathTrim.
+	TrimPrefix []string
+	// Sample is the fraction, from zero to one, of the errors whose stack
+	// is captured. Zero captures all.
+	Sample float64
+	// Time records when each error is created, the wall clock and the
+	// monotonic clock. Trace shows the time elapsed between the errors of
+	// the chain.
+	Time bool
+	// Goroutine records the ID of the goroutine that creates each error.
+	Goroutine bool
+	// Strict makes New and NewN check the verbs of the template against
+	// the arguments. If they don't match the error returned has the kind
+	// ErrFormat, the template and the problem as fields and the new error
+	// after it in the chain.
+	Strict bool
+	// Hooks are called with each new error, after the options are applied
+	// and before it is linked to a chain. The hooks must not modify it.
+	Hooks []func(err *Error)
+}
+
+// DefaultConfig captures the stack of all errors.
+var DefaultConfig = Config{Callers: true}
+
+// Capturer creates errors with a Config. A Capturer is immutable and safe
+// for concurrent use.
+type Capturer struct {
+	cfg Config
+}
+
+var capturer atomic.Pointer[Capturer]
+
+func init() {
+	capturer.Store(With(DefaultConfig))
+}
+
+// SetConfig replaces the Config used by the functions of the package.
+// Errors already created aren't changed.
+func SetConfig(cfg Config) {
+	capturer.Store(With(cfg))
+}
+
+// CurrentConfig returns the Config used by the functions of the package.
+func CurrentConfig() Config {
+	return current().Config()
+}
+
+func current() *Capturer {
+	return capturer.Load()
+}
+
+// With returns a Capturer that creates the errors with cfg instead of the
+// Config of the package, for example a package can disable the capture of
+// its errors with a Capturer of its own:
+//
+//	var errs = e.With(e.Config{})
+//
+//	err := errs.New("value %v", v)
+func With(cfg Config) *Capturer {
+	cfg.TrimPrefix = slices.Clone(cfg.TrimPrefix)
+	cfg.Hooks = slices.Clone(cfg.Hooks)
+	return &Capturer{cfg: cfg}
+}
+
+// Config returns the Config of c.
+func (c *Capturer) Config() Config {
+	cfg := c.cfg
+	cfg.TrimPrefix = slices.Clone(cfg.TrimPrefix)
+	cfg.Hooks = slices.Clone(cfg.Hooks)
+	return cfg
+}
+
+// capture decides if the stack of a new error is captured.
+func (c *Capturer) capture() bool {
+	if !c.cfg.Callers {
+		return false
+	}
+	if c.cfg.Sample <= 0 || c.cfg.Sample >= 1 {
+		return true
+	}
+	return rand.Float64() < c.cfg.Sample
+}
+
+func (c *Capturer) depth() int {
+	if c.cfg.Depth <= 0 {
+		return maxStack
+	}
+	return c.cfg.Depth
+}
+
+// New is like the function New but with the Config of c.
+func (c *Capturer) New(ie interface{}, a ...interface{}) error {
+	return c.newN(ie, 1, a...)
+}
+
+// NewN is like the function NewN but with the Config of c.
+func (c *Capturer) NewN(ie interface{}, n int, a ...interface{}) error {
+	return c.newN(ie, n+1, a...)
+}
+
+// Push is like the function Push but with the Config of c.
+func (c *Capturer) Push(e1, e2 interface{}, opts ...Option) error {
+	return c.pushN(e1, e2, 1, opts)
+}
+
+// PushN is like the function PushN but with the Config of c.
+func (c *Capturer) PushN(e1, e2 interface{}, n int, opts ...Option) error {
+	return c.pushN(e1, e2, n+1, opts)
+}
+
+// Forward is like the function Forward but with the Config of c.
+func (c *Capturer) Forward(ie interface{}) error {
+	return c.forwardN(ie, 1)
+}
+
+// ForwardN is like the function ForwardN but with the Config of c.
+func (c *Capturer) ForwardN(ie interface{}, n int) error {
+	return c.forwardN(ie, n+1)
+}
+
+// Merge is like the function Merge but with the Config of c.
+func (c *Capturer) Merge(e1, e2 interface{}) error {
+	return c.merge(e1, e2, 3)
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCapturer(t *testing.T) {
+	none := With(Config{})
+	if err := none.New(ErrDummy).(*Error); err.Debug() || err.StackTrace() != nil {
+		t.Fatal("stack captured")
+	}
+	all := With(Config{Callers: true})
+	for name, err := range map[string]error{
+		"New":      all.New("value %v", 1),
+		"NewN":     all.NewN(ErrDummy, 0),
+		"Push":     all.Push(ErrDummy, ErrStr),
+		"PushN":    all.PushN(ErrDummy, ErrStr, 0),
+		"Forward":  all.Forward(New(ErrDummy)),
+		"ForwardN": all.ForwardN(ErrDummy, 0),
+		"Merge":    all.Merge(ErrDummy, ErrSilly),
+	} {
+		e := err.(*Error)
+		if !e.Debug() || !strings.HasSuffix(e.File(), "config_test.go") || !strings.HasSuffix(e.Pkg(), "TestCapturer") {
+			t.Fatal(name, "wrong debug information", e.Pkg(), e.File())
+		}
+	}
+	if !New(ErrDummy).(*Error).Debug() {
+		t.Fatal("the Config of the package changed")
+	}
+	if err := With(Config{Callers: true, Depth: 2}).New(ErrDummy).(*Error); len(err.StackTrace()) != 2 {
+		t.Fatal("wrong depth", len(err.StackTrace()))
+	}
+	if err := deepError(With(Config{Callers: true, Depth: 2 * maxStack}), maxStack).(*Error); len(err.StackTrace()) <= maxStack {
+		t.Fatal("wrong depth", len(err.StackTrace()))
+	}
+}
+
+// deepError returns an error created n calls down the stack.
+func deepError(c *Capturer, n int) error {
+	if n == 0 {
+		return c.New(ErrDummy)
+	}
+	return deepError(c, n-1)
+}
+
+func TestConfig(t *testing.T) {
+	withConfig(t, Config{})
+	if err := New(ErrDummy).(*Error); err.Debug() {
+		t.Fatal("stack captured")
+	}
+	if CurrentConfig().Callers {
+		t.Fatal("wrong config")
+	}
+	var hooked []*Error
+	cfg := Config{Callers: true, Hooks: []func(*Error){func(err *Error) { hooked = append(hooked, err) }}}
+	SetConfig(cfg)
+	cfg.Hooks[0] = nil
+	err := New("value %v", 1, ErrTestKind).(*Error).Push(ErrStr, Permanent)
+	if len(hooked) != 2 || hooked[0].Kind() != ErrTestKind || hooked[1].Class() != Permanent || hooked[1] != err {
+		t.Fatal("hooks failed", len(hooked))
+	}
+}
+
+func TestConfigTrimPrefix(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	dir := filepath.Dir(file) + "/"
+	err := With(Config{Callers: true, Path: PathTrim, TrimPrefix: []string{"/nowhere/", dir}}).New(ErrDummy).(*Error)
+	if f := err.StackTrace()[0].File; f != "config_test.go" {
+		t.Fatal("path not trimmed", f)
+	}
+	if f := Copy(err).(*Error).StackTrace()[0].File; f != "config_test.go" {
+		t.Fatal("path not trimmed after copy", f)
+	}
+	if f := gobRoundTrip(t, err).StackTrace()[0].File; f != "config_test.go" {
+		t.Fatal("path not trimmed after gob", f)
+	}
+}
+
+func TestConfigSample(t *testing.T) {
+	c := With(Config{Callers: true, Sample: 1e-12})
+	for i := 0; i < 100; i++ {
+		if c.New(ErrDummy).(*Error).Debug() {
+			t.Fatal("sample ignored")
+		}
+	}
+}
+
+func TestConfigRace(t *testing.T) {
+	old := CurrentConfig()
+	defer SetConfig(old)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				SetConfig(Config{Callers: j%2 == 0})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = New(ErrDummy).(*Error).Push(ErrStr)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -7,37 +7,58 @@
 package e
 
 import (
-	"bytes"
 	"encoding/gob"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
+	"github.com/fcavani/e/internal/format"
 	"github.com/fcavani/types"
-	"gopkg.in/vmihailenco/msgpack.v2"
 )
 
-// Debug add file name and line number to the error
-var Debug = true
+// maxStack is the number of frames captured for each error when
+// Config.Depth is zero.
+const maxStack = 32
 
 // Error expand go error type with debug information and error trace.
 type Error struct {
 	err  error
 	args []interface{}
-	pkg  string
-	// File name
-	file string
-	// Line where the error occurred
-	line      int
+	// Program counters of the stack where the error occurred, they are
+	// only resolved to frames when needed.
+	stack []uintptr
+	// Frames of an error decoded from another process.
+	frames []Frame
+	// Capturer that captured the stack.
+	capturer  *Capturer
 	debugInfo bool
+	kind      Kind
+	class     Class
+	fields    []Field
+	// When and where the error was created, if the Config records them.
+	time      time.Time
+	mono      time.Duration
+	goroutine uint64
 	next      *Error
 }
 
+// Frame is one function call in the stack where the error occurred.
+type Frame struct {
+	// Function is the package path-qualified function name.
+	Function string `json:"function"`
+	// File is the path of the source file, see PathMode.
+	File string `json:"file"`
+	// Line is the line number in File.
+	Line int `json:"line"`
+}
+
 var once sync.Once
 
 func init() {
@@ -57,6 +78,34 @@ func (g GoError) Error() string {
 	return string(g)
 }
 
+// causes are the independent errors joined by Merge. It is compatible with
+// errors.Join, the errors are reachable through Unwrap.
+type causes []*Error
+
+func (c causes) Error() string {
+	msgs := make([]string, 0, len(c))
+	for _, err := range c {
+		msgs = append(msgs, err.Human())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (c causes) Unwrap() []error {
+	errs := make([]error, 0, len(c))
+	for _, err := range c {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func (c causes) copy() causes {
+	cp := make(causes, 0, len(c))
+	for _, err := range c {
+		cp = append(cp, err.Copy().(*Error))
+	}
+	return cp
+}
+
 type messageType uint8
 
 // types of error
@@ -65,21 +114,62 @@ const (
 	Next
 	ErrorGo
 	ErrorLocal
+	ErrorMulti
+	ErrorRegistered
+	Versioned
 )
 
-// Pkg return the package where the error occurred.
+// caller returns the frame where the error occurred.
+func (e *Error) caller() Frame {
+	if len(e.frames) > 0 {
+		return e.frames[0]
+	}
+	if len(e.stack) == 0 {
+		return Frame{}
+	}
+	f, _ := runtime.CallersFrames(e.stack).Next()
+	frame := Frame{Function: f.Function, File: f.File, Line: f.Line}
+	frame.File = e.capturer.path(frame)
+	return frame
+}
+
+// StackTrace returns the stack of function calls where the error occurred,
+// the first frame is the function that created the error.
+func (e *Error) StackTrace() []Frame {
+	if e.frames != nil {
+		return e.frames
+	}
+	if len(e.stack) == 0 {
+		return nil
+	}
+	frames := make([]Frame, 0, len(e.stack))
+	fs := runtime.CallersFrames(e.stack)
+	for {
+		f, more := fs.Next()
+		frame := Frame{Function: f.Function, File: f.File, Line: f.Line}
+		frame.File = e.capturer.path(frame)
+		frames = append(frames, frame)
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// Pkg return the qualified name of the function where the error occurred,
+// see ImportPath, Package, Receiver, Function and Closure for its parts.
 func (e *Error) Pkg() string {
-	return e.pkg
+	return e.caller().Function
 }
 
 //File returns the file name of the source where occurred the error.
 func (e *Error) File() string {
-	return e.file
+	return e.file()
 }
 
 // Line is the line of the error.
 func (e *Error) Line() int {
-	return e.line
+	return e.caller().Line
 }
 
 // Debug return true if the package, file and line are present or false if else.
@@ -92,6 +182,15 @@ func (e *Error) Next() *Error {
 	return e.next
 }
 
+// Causes return the errors merged in this link of the chain or nil if this
+// link isn't a merge of errors.
+func (e *Error) Causes() []*Error {
+	if c, ok := e.err.(causes); ok {
+		return c
+	}
+	return nil
+}
+
 //Copy create a new copy of e.
 func (e *Error) Copy() error {
 	if e == nil {
@@ -103,13 +202,23 @@ func (e *Error) Copy() error {
 	if n != nil {
 		next = n.(*Error)
 	}
+	err := e.err
+	if c, ok := err.(causes); ok {
+		err = c.copy()
+	}
 	return &Error{
-		err:       e.err,
+		err:       err,
 		args:      args,
-		pkg:       e.pkg,
-		file:      e.file,
-		line:      e.line,
+		stack:     e.stack,
+		frames:    e.frames,
+		capturer:  e.capturer,
+		kind:      e.kind,
+		class:     e.class,
+		fields:    append([]Field(nil), e.fields...),
 		debugInfo: e.debugInfo,
+		time:      e.time,
+		mono:      e.mono,
+		goroutine: e.goroutine,
 		next:      next,
 	}
 }
@@ -133,263 +242,10 @@ func Copy(ie interface{}) error {
 	}
 }
 
-// GobEncode implements custom gob encode.
-func (e *Error) GobEncode() ([]byte, error) {
-	var err error
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	switch v := e.err.(type) {
-	case *Error:
-		err = enc.Encode(ErrorLocal)
-		if err != nil {
-			return nil, err
-		}
-		err = enc.Encode(v)
-		if err != nil {
-			return nil, err
-		}
-	case error:
-		err = enc.Encode(ErrorGo)
-		if err != nil {
-			return nil, err
-		}
-		err = enc.Encode(GoError(v.Error()))
-		if err != nil {
-			return nil, err
-		}
-	default:
-		panic("type not supported")
-	}
-	err = enc.Encode(e.args)
-	if err != nil {
-		return nil, err
-	}
-	err = enc.Encode(e.pkg)
-	if err != nil {
-		return nil, err
-	}
-	err = enc.Encode(e.file)
-	if err != nil {
-		return nil, err
-	}
-	err = enc.Encode(e.line)
-	if err != nil {
-		return nil, err
-	}
-	err = enc.Encode(e.debugInfo)
-	if err != nil {
-		return nil, err
-	}
-	if e.next == nil {
-		err = enc.Encode(NextIsNill)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		err = enc.Encode(Next)
-		if err != nil {
-			return nil, err
-		}
-		err = enc.Encode(e.next)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return buf.Bytes(), nil
-}
-
-// GobDecode implements custom gob decode.
-func (e *Error) GobDecode(data []byte) error {
-	buf := bytes.NewBuffer(data)
-	dec := gob.NewDecoder(buf)
-	var msg messageType
-	err := dec.Decode(&msg)
-	if err != nil {
-		return err
-	}
-	switch msg {
-	case ErrorLocal:
-		var er *Error
-		err := dec.Decode(&er)
-		if err != nil {
-			return err
-		}
-		e.err = er
-	case ErrorGo:
-		var er GoError
-		err := dec.Decode(&er)
-		if err != nil {
-			return err
-		}
-		e.err = er
-	default:
-		return errors.New("protocol error")
-	}
-	err = dec.Decode(&e.args)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&e.pkg)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&e.file)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&e.line)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&e.debugInfo)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&msg)
-	if err != nil {
-		return err
-	}
-	switch msg {
-	case NextIsNill:
-		return nil
-	case Next:
-		err = dec.Decode(&e.next)
-		if err != nil {
-			return err
-		}
-	default:
-		return errors.New("protocol error")
-	}
-	return nil
-}
-
-// EncodeMsgpack custom msgpack encode function
-func (e *Error) EncodeMsgpack(enc *msgpack.Encoder) error {
-	var err error
-	switch v := e.err.(type) {
-	case *Error:
-		err = enc.Encode(ErrorLocal)
-		if err != nil {
-			return err
-		}
-		err = enc.Encode(v)
-		if err != nil {
-			return err
-		}
-	case error:
-		err = enc.Encode(ErrorGo)
-		if err != nil {
-			return err
-		}
-		err = enc.Encode(GoError(v.Error()))
-		if err != nil {
-			return err
-		}
-	default:
-		panic("type not supported")
-	}
-	err = enc.Encode(e.args)
-	if err != nil {
-		return err
-	}
-	err = enc.Encode(e.pkg)
-	if err != nil {
-		return err
-	}
-	err = enc.Encode(e.file)
-	if err != nil {
-		return err
-	}
-	err = enc.Encode(e.line)
-	if err != nil {
-		return err
-	}
-	err = enc.Encode(e.debugInfo)
-	if err != nil {
-		return err
-	}
-	if e.next == nil {
-		err = enc.Encode(NextIsNill)
-		if err != nil {
-			return err
-		}
-	} else {
-		err = enc.Encode(Next)
-		if err != nil {
-			return err
-		}
-		err = enc.Encode(e.next)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-// DecodeMsgpack is a custom msgpack decode function.
-func (e *Error) DecodeMsgpack(dec *msgpack.Decoder) error {
-	var msg messageType
-	err := dec.Decode(&msg)
-	if err != nil {
-		return err
-	}
-	switch msg {
-	case ErrorLocal:
-		var er *Error
-		err := dec.Decode(&er)
-		if err != nil {
-			return err
-		}
-		e.err = er
-	case ErrorGo:
-		var er GoError
-		err := dec.Decode(&er)
-		if err != nil {
-			return err
-		}
-		e.err = er
-	default:
-		return errors.New("protocol error")
-	}
-	err = dec.Decode(&e.args)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&e.pkg)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&e.file)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&e.line)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&e.debugInfo)
-	if err != nil {
-		return err
-	}
-	err = dec.Decode(&msg)
-	if err != nil {
-		return err
-	}
-	switch msg {
-	case NextIsNill:
-		return nil
-	case Next:
-		err = dec.Decode(&e.next)
-		if err != nil {
-			return err
-		}
-	default:
-		return errors.New("protocol error")
-	}
-	return nil
-}
-
 func (e *Error) formatError() string {
+	if c, ok := e.err.(causes); ok {
+		return c.Error()
+	}
 	return fmt.Sprintf(e.err.Error(), e.args...)
 }
 
@@ -404,7 +260,8 @@ func (e *Error) Error() string {
 		return "nil"
 	}
 	if e.debugInfo {
-		return fmt.Sprintf("%v - %v - %v: %v", e.pkg, e.file, strconv.Itoa(e.line), e.formatError())
+		caller := e.caller()
+		return fmt.Sprintf("%v - %v - %v: %v", caller.Function, e.file(), strconv.Itoa(caller.Line), e.formatError())
 	}
 	return e.formatError()
 }
@@ -423,11 +280,38 @@ func (e *Error) GoString() string {
 		return "nil"
 	}
 	if e.debugInfo {
-		return fmt.Sprintf("package: %v - file: %v - line: %v - error: %v", e.pkg, e.file, strconv.Itoa(e.line), e.formatError())
+		caller := e.caller()
+		return fmt.Sprintf("package: %v - file: %v - line: %v - error: %v", caller.Function, e.file(), strconv.Itoa(caller.Line), e.formatError())
 	}
 	return fmt.Sprintf("%#v", e.formatError())
 }
 
+// Format implements fmt.Formatter. The verbs %s and %v print the human
+// readable message, %q the quoted message, %+v the trace of the chain with
+// the stack of each error and %#v the same as GoString.
+func (e *Error) Format(s fmt.State, verb rune) {
+	if e == nil {
+		io.WriteString(s, "nil")
+		return
+	}
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, e.trace("", "", (*Error).formatStack))
+			return
+		}
+		if s.Flag('#') {
+			io.WriteString(s, e.GoString())
+			return
+		}
+		fmt.Fprintf(s, fmt.FormatString(s, verb), e.Human())
+	case 's', 'q':
+		fmt.Fprintf(s, fmt.FormatString(s, verb), e.Human())
+	default:
+		fmt.Fprintf(s, "%%!%c(*e.Error=%s)", verb, e.Human())
+	}
+}
+
 // Arguments return the arguments of one error.
 func (e *Error) Arguments() []interface{} {
 	return e.args
@@ -469,77 +353,98 @@ func String(i interface{}) string {
 	return msg
 }
 
-func (e *Error) last() *Error {
-	prev := e
-	next := e.next
-	for next != nil {
-		prev = next
-		next = next.next
+// graft returns a copy of the chain e with next after its last error. The
+// chain e isn't modified, next is shared.
+func (e *Error) graft(next *Error) *Error {
+	cp := *e
+	if e.next == nil {
+		cp.next = next
+	} else {
+		cp.next = e.next.graft(next)
 	}
-	return prev
+	return &cp
 }
 
-func (e *Error) push(ie interface{}, n int) *Error {
+func (c *Capturer) push(e *Error, ie interface{}, n int, opts []Option) *Error {
 	if ie == nil {
 		return nil
 	}
-	var err *Error
 	if e2, ok := ie.(*Error); ok {
-		err = e2.last()
-	} else {
-		err = newError(ie, n).(*Error)
+		if e2 == nil {
+			return nil
+		}
+		ne := e2.graft(e)
+		applyOptions(ne, opts)
+		return ne
 	}
+	err := c.newError(ie, n, opts)
 	if err == nil {
 		return nil
 	}
-	err.next = e
-	return err
+	ne := err.(*Error)
+	ne.next = e
+	return ne
 }
 
 // Push one error on the top of the stack. ie must be *Error, error or string.
-func (e *Error) Push(ie interface{}) *Error {
-	return e.push(ie, 3)
+// The options, like a Kind or a Class, are applied to the pushed error.
+// Push returns a new chain, e and ie aren't modified.
+func (e *Error) Push(ie interface{}, opts ...Option) *Error {
+	return current().push(e, ie, 3, opts)
 }
 
 // Push e2 error on the top of the stack (e1 error). Free function to use with other
 // types of error beside the *Error. e1 must be *Error or error
-// and e2 must be *Error, error or string.
-func Push(e1, e2 interface{}) error {
-	return PushN(e1, e2, 1)
+// and e2 must be *Error, error or string. The options are applied to e2.
+func Push(e1, e2 interface{}, opts ...Option) error {
+	return PushN(e1, e2, 1, opts...)
 }
 
 // PushN like Push but with the stack deep to get the file name.
-func PushN(e1, e2 interface{}, n int) error {
+func PushN(e1, e2 interface{}, n int, opts ...Option) error {
+	return current().pushN(e1, e2, n+1, opts)
+}
+
+func (c *Capturer) pushN(e1, e2 interface{}, n int, opts []Option) error {
 	if e1 == nil {
+		var ne *Error
 		if e2b, ok := e2.(*Error); ok {
-			return e2b.forward(3 + n)
+			ne = c.forward(e2b, 3+n)
+			if ne != nil {
+				applyOptions(ne, opts)
+			}
+		} else if err := c.newError(e2, 2+n, opts); err != nil {
+			ne = err.(*Error)
+		}
+		if ne == nil {
+			return nil
 		}
-		return newError(e2, 2+n)
+		return ne
 	}
 	switch val := e1.(type) {
 	case *Error:
-		return val.push(e2, 3+n)
+		return c.push(val, e2, 3+n, opts)
 	case error:
-		return newError(val, 2+n).(*Error).push(e2, 3+n)
+		return c.push(c.newError(val, 2+n, nil).(*Error), e2, 3+n, opts)
 	case string:
-		return newError(val, 2+n).(*Error).push(e2, 3+n)
+		return c.push(c.newError(val, 2+n, nil).(*Error), e2, 3+n, opts)
 	default:
 		panic("invalid type, e1 must be *Error")
 	}
 }
 
-func (e *Error) forward(n int) *Error {
+func (c *Capturer) forward(e *Error, n int) *Error {
 	if e == nil || e.err == nil {
 		return nil
 	}
-	ne := newError(e, n).(*Error)
+	ne := c.newError(e, n, nil).(*Error)
 	ne.next = e
 	return ne
 }
 
 // Forward the error. Only stack the error menssage and the debug data.
 func (e *Error) Forward() *Error {
-	return e.forward(3)
+	return current().forward(e, 3)
 }
 
 // Forward the error. Only stack the error menssage and the debug data.
@@ -552,6 +457,10 @@ func Forward(ie interface{}) error {
 // ForwardN skip n levels from the stack when login the
 // trace.
 func ForwardN(ie interface{}, n int) error {
+	return current().forwardN(ie, n+1)
+}
+
+func (c *Capturer) forwardN(ie interface{}, n int) error {
 	if ie == nil {
 		return nil
 	}
@@ -560,15 +469,15 @@ func ForwardN(ie interface{}, n int) error {
 		if val == nil {
 			return nil
 		}
-		ret := val.forward(3 + n)
+		ret := c.forward(val, 3+n)
 		if ret == nil {
 			return nil
 		}
 		return ret
 	case error:
-		return newError(val, 2+n)
+		return c.newError(val, 2+n, nil)
 	case string:
-		return newError(val, 2+n)
+		return c.newError(val, 2+n, nil)
 	default:
 		panic("invalid type")
 	}
@@ -601,6 +510,44 @@ func (e *Error) Equal(ie interface{}) bool {
 	}
 }
 
+// Unwrap returns the next error in the chain. The last error in the chain
+// unwraps to the error that it wraps, so errors.Unwrap, errors.Is and
+// errors.As walk the whole chain.
+func (e *Error) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	if e.next != nil {
+		return e.next
+	}
+	return e.err
+}
+
+// Is reports whether the error wrapped by this link is target. If target
+// is an *Error the wrapped errors are compared, so a sentinel *Error
+// matches the errors forwarded or pushed from it. Use Equal to compare
+// the messages.
+func (e *Error) Is(target error) bool {
+	if e == nil || e.err == nil || target == nil {
+		return false
+	}
+	if t, ok := target.(*Error); ok {
+		if t == nil || t.err == nil {
+			return false
+		}
+		return errors.Is(e.err, t.err)
+	}
+	return errors.Is(e.err, target)
+}
+
+// As finds the first error wrapped by this link that matches target.
+func (e *Error) As(target interface{}) bool {
+	if e == nil || e.err == nil {
+		return false
+	}
+	return errors.As(e.err, target)
+}
+
 // Equal compare if the errors are the same. l must be *Error and r must be
 // *Error, error or string.
 func Equal(l, r interface{}) bool {
@@ -617,20 +564,46 @@ func Equal(l, r interface{}) bool {
 	}
 }
 
+// walk calls fn for each error in the chain and in the chains of the merged
+// errors, depth first. deep is the distance of the error from the top of the
+// tree. walk stops when fn returns false.
+func (e *Error) walk(deep int, fn func(err *Error, deep int) bool) bool {
+	for err := e; err != nil; err = err.next {
+		if !fn(err, deep) {
+			return false
+		}
+		switch v := err.err.(type) {
+		case causes:
+			for _, cause := range v {
+				if !cause.walk(deep+1, fn) {
+					return false
+				}
+			}
+		case *Error:
+			if !v.walk(deep+1, fn) {
+				return false
+			}
+		}
+		deep = deep + 1
+	}
+	return true
+}
+
 // Find an error in the chain. ie must be
 // *Error, error or string.
 func (e *Error) Find(ie interface{}) int {
 	if ie == nil {
 		return -1
 	}
-	deep := 0
-	for err := e; err != nil; err = err.next {
+	found := -1
+	e.walk(0, func(err *Error, deep int) bool {
 		if err.Equal(ie) {
-			return deep
+			found = deep
+			return false
 		}
-		deep = deep + 1
-	}
-	return -1
+		return true
+	})
+	return found
 }
 
 // Find an error in the chain. e must be *Error and ie must be
@@ -649,10 +622,37 @@ func Find(e, ie interface{}) int {
 	}
 }
 
-// Trace the error and return a string.
+// Trace the error and return a string. The fields of each error follow its
+// message, then the time elapsed since the error after it in the chain, if
+// both recorded the time, and the chains of merged errors are indented
+// below the error that merges them.
 func (e *Error) Trace() (s string) {
+	return e.trace("", "", nil)
+}
+
+// trace renders the chain, if detail isn't nil what it returns is rendered
+// after each error.
+func (e *Error) trace(head, indent string, detail func(err *Error, indent string) string) (s string) {
 	for err := e; err != nil; err = err.next {
-		s = s + fmt.Sprintln(err)
+		s = s + head + err.Error() + err.formatFields() + err.formatDelta() + "\n"
+		head = indent
+		if detail != nil {
+			s = s + detail(err, indent)
+		}
+		if c, ok := err.err.(causes); ok {
+			for _, cause := range c {
+				s = s + cause.trace(indent+"  - ", indent+"    ", detail)
+			}
+		}
+	}
+	return
+}
+
+// formatStack renders the stack of the error.
+func (e *Error) formatStack(indent string) (s string) {
+	for _, f := range e.StackTrace() {
+		s = s + indent + "\t" + f.Symbol().String() + "\n"
+		s = s + indent + "\t\t" + f.File + ":" + strconv.Itoa(f.Line) + "\n"
 	}
 	return
 }
@@ -672,11 +672,20 @@ func Trace(ie interface{}) string {
 	}
 }
 
-func newError(ie interface{}, level int, a ...interface{}) (err error) {
+// newError creates an error with the current Capturer.
+func newError(ie interface{}, level int, a ...interface{}) error {
+	return current().newError(ie, level+1, nil, a...)
+}
+
+// newError creates an error from ie, the options are applied to it before
+// the hooks are called.
+func (c *Capturer) newError(ie interface{}, level int, opts []Option, a ...interface{}) (err error) {
 	if ie == nil {
 		return
 	}
 	var e error
+	var kind Kind
+	var class Class
 	switch val := ie.(type) {
 	case *Error:
 		if val == nil || val.err == nil {
@@ -684,6 +693,15 @@ func newError(ie interface{}, level int, a ...interface{}) (err error) {
 		}
 		e = val.err
 		a = val.args
+		kind = val.kind
+		class = val.class
+		if c, ok := e.(causes); ok {
+			// Only the message, the merged errors stay in val.
+			e = GoError(c.Error())
+		}
+	case Kind:
+		e = val
+		kind = val
 	case error:
 		if val == nil {
 			return nil
@@ -694,67 +712,104 @@ func newError(ie interface{}, level int, a ...interface{}) (err error) {
 	default:
 		panic("invalid type")
 	}
-	pc, file, line, ok := runtime.Caller(level)
-	if ok && Debug {
-		s := strings.Split(file, "/")
-		l := len(s)
-		var file string
-		if l >= 2 {
-			file = strings.Join(s[l-2:l], "/")
+	ne := &Error{
+		err:   e,
+		args:  a,
+		kind:  kind,
+		class: class,
+	}
+	if c.capture() {
+		var buf [maxStack]uintptr
+		pcs := buf[:]
+		if d := c.depth(); d > maxStack {
+			pcs = make([]uintptr, d)
 		} else {
-			file = s[0]
+			pcs = pcs[:d]
 		}
-		f := runtime.FuncForPC(pc)
-		err = &Error{
-			err:       e,
-			args:      a,
-			pkg:       f.Name(),
-			file:      file,
-			line:      line,
-			debugInfo: true,
+		n := runtime.Callers(level+1, pcs)
+		if n > 0 {
+			ne.stack = make([]uintptr, n)
+			copy(ne.stack, pcs[:n])
+			ne.capturer = c
+			ne.debugInfo = true
 		}
-		return
 	}
-	err = &Error{
-		err:       e,
-		args:      a,
-		debugInfo: false,
+	if c.cfg.Time {
+		ne.time = time.Now()
+		ne.mono = time.Since(start)
 	}
-	return
+	if c.cfg.Goroutine {
+		ne.goroutine = goroutineID()
+	}
+	applyOptions(ne, opts)
+	for _, hook := range c.cfg.Hooks {
+		hook(ne)
+	}
+	return ne
+}
+
+// newOptions is like newError but a may have options, they are applied to
+// the new error.
+func (c *Capturer) newOptions(ie interface{}, level int, a ...interface{}) error {
+	var template string
+	switch val := ie.(type) {
+	case string:
+		template = val
+	case *Error:
+	case error:
+		template = val.Error()
+	}
+	a, opts := splitOptions(template, a)
+	if e, ok := ie.(*Error); ok {
+		if len(opts) == 0 {
+			return e
+		}
+		cp := *e
+		applyOptions(&cp, opts)
+		return &cp
+	}
+	err := c.newError(ie, level+1, opts, a...)
+	if err == nil || !c.cfg.Strict {
+		return err
+	}
+	ne := err.(*Error)
+	er := format.Check(ne.err.Error(), len(ne.args))
+	if er != nil {
+		fe := c.newError(ErrFormat, level+1, []Option{
+			Field{Key: "template", Value: ne.err.Error()},
+			Field{Key: "problem", Value: er.Error()},
+		}).(*Error)
+		fe.next = ne
+		return fe
+	}
+	return ne
 }
 
 // New initiates an error from a string, error or *Error. a is
 // the verb in the error string that will be replaced when
 // Error and GoString functions is called. The valids verbs are
-// the same verbs in the fmt package.
+// the same verbs in the fmt package. The options in a, like a Kind,
+// are applied to the error and aren't used as verbs.
 func New(ie interface{}, a ...interface{}) error {
-	if ie == nil {
-		return nil
-	}
-	switch err := ie.(type) {
-	case *Error:
-		return err
-	case string:
-		return newError(err, 2, a...)
-	case error:
-		return newError(err, 2, a...)
-	default:
-		panic("invalid error type")
-	}
+	return current().newN(ie, 1, a...)
 }
 
 // NewN like New but with the stack deep to get the file name.
 func NewN(ie interface{}, n int, a ...interface{}) error {
+	return current().newN(ie, n+1, a...)
+}
+
+func (c *Capturer) newN(ie interface{}, n int, a ...interface{}) error {
 	if ie == nil {
 		return nil
 	}
 	switch err := ie.(type) {
 	case *Error:
-		return err
+		return c.newOptions(err, 2+n, a...)
 	case string:
-		return newError(err, 2+n, a...)
+		return c.newOptions(err, 2+n, a...)
 	case error:
-		return newError(err, 2+n, a...)
+		return c.newOptions(err, 2+n, a...)
 	default:
 		panic("invalid error type")
 	}
@@ -794,14 +849,15 @@ func Contains(ie interface{}, sub string) bool {
 // FindStr find a sub string int the chain of error and return
 // the deep of the error.
 func (e *Error) FindStr(sub string) int {
-	deep := 0
-	for err := e; err != nil; err = err.next {
+	found := -1
+	e.walk(0, func(err *Error, deep int) bool {
 		if err.Contains(sub) {
-			return deep
+			found = deep
+			return false
 		}
-		deep = deep + 1
-	}
-	return -1
+		return true
+	})
+	return found
 }
 
 // FindStr in a error.
@@ -820,7 +876,12 @@ func FindStr(ie interface{}, sub string) int {
 	}
 }
 
+// newm creates an error with the current Capturer if e1 isn't an *Error.
 func newm(e1 interface{}) *Error {
+	return current().newm(e1, 3)
+}
+
+func (c *Capturer) newm(e1 interface{}, level int) *Error {
 	if e1 == nil {
 		return nil
 	}
@@ -828,54 +889,51 @@ func newm(e1 interface{}) *Error {
 	case *Error:
 		return val
 	case error:
-		return newError(val, 3).(*Error)
+		return c.newError(val, level, nil).(*Error)
 	case string:
-		return newError(val, 3).(*Error)
+		return c.newError(val, level, nil).(*Error)
 	default:
 		panic("invalid type")
 	}
 }
 
-// Merge two errors.
+// Merge two errors. The result is a new error with e1 and e2 as
+// independent causes, their chains are kept apart. e1 and e2 aren't modified.
 func Merge(e1, e2 interface{}) error {
+	return current().merge(e1, e2, 3)
+}
+
+func (c *Capturer) merge(e1, e2 interface{}, level int) error {
 	if e1 == nil && e2 == nil {
 		return nil
 	}
 	if e1 != nil && e2 == nil {
-		return newError(e1, 2)
+		return c.newError(e1, level, nil)
 	}
 	if e1 == nil && e2 != nil {
-		return newError(e2, 2)
+		return c.newError(e2, level, nil)
 	}
 	switch val := e2.(type) {
 	case *Error:
 		if val == nil {
-			return newm(e1)
+			return c.newm(e1, level+1)
 		}
-		prev := val
-		for err := val.next; err != nil; err = err.next {
-			prev = err
-		}
-		prev.next = newm(e1)
-		return val
 	case error:
 		if val == nil {
-			return newm(e1)
+			return c.newm(e1, level+1)
 		}
-		prev := newError(val, 2).(*Error)
-		prev.next = newm(e1)
-		return prev
 	case string:
 		if val == "" {
-			return newm(e1)
+			return c.newm(e1, level+1)
 		}
-		prev := newError(val, 2).(*Error)
-		prev.next = newm(e1)
-		return prev
 	default:
 		panic("invalid type")
 	}
-	//panic("¯|_(ツ)_/¯")
+	c1 := c.newm(e1, level+1)
+	if c1 == nil {
+		return c.newm(e2, level+1)
+	}
+	return c.newError(causes{c1, c.newm(e2, level+1)}, level, nil)
 }
 
 // Human returns a near human readable form.
@@ -7,10 +7,19 @@
 package e
 
 import (
+	"bytes"
+	"encoding/gob"
 	"errors"
+	"fmt"
 	"io"
+	"os"
+	"reflect"
+	"runtime"
 	"strconv"
+	"strings"
 	"testing"
+
+	"gopkg.in/vmihailenco/msgpack.v2"
 )
 
 // Silly errors
@@ -262,28 +271,40 @@ func TestCopy2(t *testing.T) {
 
 func TestMerge(t *testing.T) {
 	err := Merge(New("1"), Merge("2", "3")).(*Error)
-	count := 3
-	for e := err; e != nil; e = e.next {
-		i, err := strconv.Atoi(e.err.Error())
-		if err != nil {
-			t.Fatal(err)
-		}
-		if i != count {
-			t.Fatal("errors aren't in order")
-		}
-		count--
+	if err.Next() != nil {
+		t.Fatal("merge must not have a next error")
+	}
+	c := err.Causes()
+	if len(c) != 2 {
+		t.Fatal("wrong number of causes", len(c))
 	}
-	err = Merge(New("1"), Merge("2", errors.New("3"))).(*Error)
-	count = 3
-	for e := err; e != nil; e = e.next {
-		i, err := strconv.Atoi(e.err.Error())
+	if c[0].err.Error() != "1" {
+		t.Fatal("error value is wrong")
+	}
+	c = c[1].Causes()
+	if len(c) != 2 {
+		t.Fatal("wrong number of causes", len(c))
+	}
+	for i, e := range c {
+		n, err := strconv.Atoi(e.err.Error())
 		if err != nil {
 			t.Fatal(err)
 		}
-		if i != count {
+		if n != i+2 {
 			t.Fatal("errors aren't in order")
 		}
-		count--
+	}
+	if err.Human() != "1; 2; 3" {
+		t.Fatal("wrong message:", err.Human())
+	}
+	if deep := Find(err, "3"); deep != 2 {
+		t.Fatal("Find failed:", deep)
+	}
+	if deep := FindStr(err, "2"); deep != 0 {
+		t.Fatal("FindStr failed:", deep)
+	}
+	if !errors.Is(Merge(io.EOF, ErrDummy), ErrDummy) {
+		t.Fatal("Is failed")
 	}
 	er := Merge(nil, nil)
 	if er != nil {
@@ -304,3 +325,256 @@ func TestMerge(t *testing.T) {
 		t.Fatal("error value is wrong")
 	}
 }
+
+const traceMerge = `4
+1; 2
+  - 1
+    0
+  - 2
+`
+
+// withConfig sets the Config of the package until the end of the test.
+func withConfig(t *testing.T, cfg Config) {
+	old := CurrentConfig()
+	SetConfig(cfg)
+	t.Cleanup(func() {
+		SetConfig(old)
+	})
+}
+
+func TestTraceMerge(t *testing.T) {
+	withConfig(t, Config{})
+	err := Merge(New("0").(*Error).Push("1"), "2").(*Error).Push("4")
+	if tr := err.Trace(); tr != traceMerge {
+		t.Fatalf("wrong trace:\n%v", tr)
+	}
+	fwd := Forward(err).(*Error)
+	if tr := fwd.Trace(); tr != "4\n"+traceMerge {
+		t.Fatalf("wrong trace:\n%v", tr)
+	}
+	cp := Copy(err).(*Error)
+	if tr := cp.Trace(); tr != traceMerge {
+		t.Fatalf("wrong trace:\n%v", tr)
+	}
+	if cp.next.Causes()[0] == err.next.Causes()[0] {
+		t.Fatal("causes weren't copied")
+	}
+}
+
+func TestEncodeMerge(t *testing.T) {
+	err := Merge(New("0").(*Error).Push("1"), "2").(*Error).Push("4")
+	buf := bytes.NewBuffer([]byte{})
+	if er := gob.NewEncoder(buf).Encode(err); er != nil {
+		t.Fatal(er)
+	}
+	var gerr *Error
+	if er := gob.NewDecoder(buf).Decode(&gerr); er != nil {
+		t.Fatal(er)
+	}
+	if gerr.Trace() != err.Trace() {
+		t.Fatalf("gob failed:\n%v", gerr.Trace())
+	}
+	b, er := msgpack.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var merr *Error
+	if er := msgpack.Unmarshal(b, &merr); er != nil {
+		t.Fatal(er)
+	}
+	if merr.Trace() != err.Trace() {
+		t.Fatalf("msgpack failed:\n%v", merr.Trace())
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	err := New(ErrDummy).(*Error).Push(ErrStr).Push(ErrSilly)
+	count := 0
+	var last error
+	for e := error(err); e != nil; e = errors.Unwrap(e) {
+		last = e
+		count++
+	}
+	if count != 4 {
+		t.Fatal("Unwrap failed:", count)
+	}
+	if last != ErrDummy {
+		t.Fatal("Unwrap didn't reach the wrapped error:", last)
+	}
+}
+
+func TestIs(t *testing.T) {
+	err := New(ErrDummy).(*Error).Push(ErrStr).Push(ErrSilly)
+	if !errors.Is(err, ErrDummy) {
+		t.Fatal("Is failed.")
+	}
+	if !errors.Is(err, ErrSilly) {
+		t.Fatal("Is failed (2).")
+	}
+	if errors.Is(err, ErrAnother) {
+		t.Fatal("Is failed (3).")
+	}
+	if errors.Is(err, errors.New(ErrDummy.Error())) {
+		t.Fatal("Is must not compare the message.")
+	}
+	sentinel := New(ErrAnother).(*Error)
+	fwd := Forward(Push(sentinel, ErrStr))
+	if !errors.Is(fwd, sentinel) {
+		t.Fatal("Is failed (4).")
+	}
+	if errors.Is(err, sentinel) {
+		t.Fatal("Is failed (5).")
+	}
+	if !errors.Is(Forward(io.EOF), io.EOF) {
+		t.Fatal("Is failed (6).")
+	}
+}
+
+func TestAs(t *testing.T) {
+	perr := &os.PathError{Op: "open", Path: "/foo", Err: os.ErrNotExist}
+	err := New(perr).(*Error).Push(ErrStr).Push(ErrSilly)
+	var target *os.PathError
+	if !errors.As(err, &target) {
+		t.Fatal("As failed.")
+	}
+	if target != perr {
+		t.Fatal("As returned the wrong error.")
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("Is failed.")
+	}
+	var e *Error
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &e) {
+		t.Fatal("As failed (2).")
+	}
+	if e != err {
+		t.Fatal("As returned the wrong error (2).")
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	err := New(ErrDummy).(*Error)
+	_, file, line, _ := runtime.Caller(0)
+	if err.Pkg() != "github.com/fcavani/e.TestStackTrace" {
+		t.Fatal("wrong package:", err.Pkg())
+	}
+	if err.File() != shortFile(file) || !strings.HasSuffix(err.File(), "/error_test.go") {
+		t.Fatal("wrong file:", err.File())
+	}
+	if err.Line() != line-1 {
+		t.Fatal("wrong line:", err.Line())
+	}
+	st := err.StackTrace()
+	if len(st) < 2 {
+		t.Fatal("stack is too short", len(st))
+	}
+	if st[0].Function != err.Pkg() || st[0].File != shortFile(file) || st[0].Line != line-1 {
+		t.Fatalf("wrong frame: %#v", st[0])
+	}
+	if st[1].Function != "testing.tRunner" {
+		t.Fatalf("wrong frame: %#v", st[1])
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	if er := gob.NewEncoder(buf).Encode(err); er != nil {
+		t.Fatal(er)
+	}
+	var gerr *Error
+	if er := gob.NewDecoder(buf).Decode(&gerr); er != nil {
+		t.Fatal(er)
+	}
+	if !reflect.DeepEqual(gerr.StackTrace(), st) {
+		t.Fatal("gob stack differ")
+	}
+	if gerr.Error() != err.Error() {
+		t.Fatal("gob error differ", gerr.Error())
+	}
+	b, er := msgpack.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var merr *Error
+	if er := msgpack.Unmarshal(b, &merr); er != nil {
+		t.Fatal(er)
+	}
+	if !reflect.DeepEqual(merr.StackTrace(), st) {
+		t.Fatal("msgpack stack differ")
+	}
+	if merr.Error() != err.Error() {
+		t.Fatal("msgpack error differ", merr.Error())
+	}
+}
+
+func TestNoDebug(t *testing.T) {
+	err := With(Config{}).New(ErrDummy).(*Error)
+	if err.Debug() || err.StackTrace() != nil || err.Pkg() != "" || err.Line() != 0 {
+		t.Fatal("debug info present")
+	}
+	buf := bytes.NewBuffer([]byte{})
+	if er := gob.NewEncoder(buf).Encode(err); er != nil {
+		t.Fatal(er)
+	}
+	var gerr *Error
+	if er := gob.NewDecoder(buf).Decode(&gerr); er != nil {
+		t.Fatal(er)
+	}
+	if gerr.Debug() || gerr.StackTrace() != nil || gerr.Error() != ErrDummy.Error() {
+		t.Fatal("gob failed", gerr.Error())
+	}
+}
+
+func TestFormat(t *testing.T) {
+	err := New("bad value %v", 42).(*Error).Push(ErrStr)
+	if s := fmt.Sprintf("%s", err); s != ErrStr {
+		t.Fatal("verb s failed:", s)
+	}
+	if s := fmt.Sprintf("%v", err); s != ErrStr {
+		t.Fatal("verb v failed:", s)
+	}
+	if s := fmt.Sprintf("%v", err.next); s != "bad value 42" {
+		t.Fatal("verb v failed:", s)
+	}
+	if s := fmt.Sprintf("%q", err.next); s != `"bad value 42"` {
+		t.Fatal("verb q failed:", s)
+	}
+	if s := fmt.Sprintf("%#v", err); s != err.GoString() {
+		t.Fatal("verb #v failed:", s)
+	}
+	if s := fmt.Sprintf("%d", err); s != "%!d(*e.Error=string error)" {
+		t.Fatal("verb d failed:", s)
+	}
+	if s := fmt.Sprintf("%v", (*Error)(nil)); s != "nil" {
+		t.Fatal("nil failed:", s)
+	}
+	s := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(s, err.Error()+"\n\tgithub.com/fcavani/e.TestFormat\n\t\t") {
+		t.Fatalf("%%+v failed:\n%v", s)
+	}
+	if !strings.Contains(s, "\n"+err.next.Error()+"\n\tgithub.com/fcavani/e.TestFormat\n") {
+		t.Fatalf("%%+v failed:\n%v", s)
+	}
+	if strings.Count(s, "testing.tRunner") != 2 {
+		t.Fatalf("%%+v failed:\n%v", s)
+	}
+	wrapped := fmt.Errorf("wrapped: %w", err)
+	if wrapped.Error() != "wrapped: "+ErrStr {
+		t.Fatal("%w failed:", wrapped)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	withConfig(t, Config{Callers: true, Strict: true})
+	err := New("value %v", 1, 2, ErrTestKind).(*Error)
+	if err.Kind() != ErrFormat || err.next == nil || err.next.Kind() != ErrTestKind {
+		t.Fatal("mismatch not found", err.Trace())
+	}
+	if err.Fields()["template"] != "value %v" || !strings.HasSuffix(err.File(), "error_test.go") {
+		t.Fatal("wrong error", err.Trace())
+	}
+	if KindOf(NewN("value %z", 0, 1)) != ErrFormat || KindOf(New("value")) == ErrFormat {
+		t.Fatal("strict mode failed")
+	}
+	if KindOf(New("value %v", 1, ErrTestKind)) != ErrTestKind || KindOf(New(ErrDummy)) == ErrFormat {
+		t.Fatal("strict mode failed")
+	}
+}
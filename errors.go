@@ -7,8 +7,13 @@ package e
 
 // Errors
 const (
-	ErrEmptyString = "empty string"
-	ErrInvalidType = "type is invalid"
-	ErrInvalidLength = "length is invalid"
-	ErrInvalidInterface = "invalid interface"
+	ErrEmptyString      Kind = "empty string"
+	ErrInvalidType      Kind = "type is invalid"
+	ErrInvalidLength    Kind = "length is invalid"
+	ErrInvalidInterface Kind = "invalid interface"
+	// ErrPanic is the kind of the errors made from recovered panics.
+	ErrPanic Kind = "panic"
+	// ErrFormat is the kind of the errors returned by New in strict mode
+	// when the verbs don't match the arguments.
+	ErrFormat Kind = "format doesn't match the arguments"
 )
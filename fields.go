@@ -0,0 +1,61 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is a key and value that gives context to an error. A Field is an
+// option, passed to New among the arguments it is added to the new error.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func (f Field) apply(e *Error) {
+	e.fields = append(e.fields[:len(e.fields):len(e.fields)], f)
+}
+
+// With returns a copy of this error of the chain with the field added. e
+// isn't modified.
+func (e *Error) With(key string, value interface{}) *Error {
+	if e == nil {
+		return nil
+	}
+	cp := *e
+	Field{Key: key, Value: value}.apply(&cp)
+	return &cp
+}
+
+// Fields returns the fields of all errors in the chain. If a key is in
+// more than one error the value nearer the top of the chain is returned, in
+// the same error the last one added wins.
+func (e *Error) Fields() map[string]interface{} {
+	fields := make(map[string]interface{})
+	for err := e; err != nil; err = err.next {
+		for i := len(err.fields) - 1; i >= 0; i-- {
+			f := err.fields[i]
+			if _, found := fields[f.Key]; !found {
+				fields[f.Key] = f.Value
+			}
+		}
+	}
+	return fields
+}
+
+// formatFields renders the fields of this error of the chain.
+func (e *Error) formatFields() string {
+	if len(e.fields) == 0 {
+		return ""
+	}
+	s := make([]string, 0, len(e.fields))
+	for _, f := range e.fields {
+		s = append(s, fmt.Sprintf("%v=%v", f.Key, f.Value))
+	}
+	return " [" + strings.Join(s, " ") + "]"
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	err := New("value %v", 1, Field{Key: "id", Value: 42}, ErrTestKind).(*Error)
+	if err.Human() != "value 1" || err.Kind() != ErrTestKind {
+		t.Fatal("wrong error", err.Human())
+	}
+	if !reflect.DeepEqual(err.Fields(), map[string]interface{}{"id": 42}) {
+		t.Fatal("wrong fields", err.Fields())
+	}
+	user := err.With("user", "foo")
+	if !reflect.DeepEqual(user.Fields(), map[string]interface{}{"id": 42, "user": "foo"}) {
+		t.Fatal("wrong fields", user.Fields())
+	}
+	other := err.With("user", "bar")
+	if user.Fields()["user"] != "foo" || other.Fields()["user"] != "bar" {
+		t.Fatal("With changed a shared error")
+	}
+	if len(err.Fields()) != 1 {
+		t.Fatal("With changed the error")
+	}
+	if user.With("id", 43).Fields()["id"] != 43 {
+		t.Fatal("the last field didn't win")
+	}
+	chain := New(ErrDummy, Field{Key: "id", Value: 1}).(*Error).Push(user)
+	if chain.Fields()["id"] != 42 || chain.Fields()["user"] != "foo" {
+		t.Fatal("wrong fields", chain.Fields())
+	}
+	if len(Forward(user).(*Error).fields) != 0 {
+		t.Fatal("forward copied the fields")
+	}
+	if !reflect.DeepEqual(Copy(chain).(*Error).Fields(), chain.Fields()) {
+		t.Fatal("copy lost the fields")
+	}
+	if !strings.Contains(user.Trace(), "value 1 [id=42 user=foo]\n") {
+		t.Fatal("wrong trace", user.Trace())
+	}
+}
+
+func TestFieldsCodecs(t *testing.T) {
+	err := New(ErrDummy, Field{Key: "arg", Value: customArg{Name: "foo", Count: 1}}).(*Error).
+		Push(New("value %v", 1).(*Error).With("id", 42).With("none", nil))
+	for name, rt := range map[string]func(*testing.T, *Error) *Error{"gob": gobRoundTrip, "msgpack": msgpackRoundTrip} {
+		derr := rt(t, err)
+		if !reflect.DeepEqual(derr.Fields(), err.Fields()) {
+			t.Fatal(name, "wrong fields", derr.Fields())
+		}
+	}
+	b, er := json.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var jerr *Error
+	if er := json.Unmarshal(b, &jerr); er != nil {
+		t.Fatal(er)
+	}
+	fields := jerr.Fields()
+	if fields["id"] != 42 || fields["arg"] != "{foo 1}" || jerr.fields[0].Key != "id" {
+		t.Fatal("json: wrong fields", fields)
+	}
+}
+
+func TestFieldsLog(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	logger := slog.New(slog.NewTextHandler(buf, nil))
+	logger.Info("failed", "err", New(ErrDummy).(*Error).With("id", 42))
+	if !strings.Contains(buf.String(), "err.fields.id=42") {
+		t.Fatal("wrong log", buf.String())
+	}
+}
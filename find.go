@@ -0,0 +1,74 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"errors"
+	"iter"
+	"reflect"
+	"regexp"
+)
+
+// All returns an iterator over the errors of the chain, of the chains of
+// the merged errors and of the chains of the wrapped *Error, like the ones
+// decoded, depth first, with their deep. The deep is the same returned by
+// Find.
+func (e *Error) All() iter.Seq2[int, *Error] {
+	return func(yield func(int, *Error) bool) {
+		e.walk(0, func(err *Error, deep int) bool {
+			return yield(deep, err)
+		})
+	}
+}
+
+// FindFunc returns the first error in the chain for which pred returns
+// true and its deep, or nil and -1 if there isn't one.
+func (e *Error) FindFunc(pred func(err *Error) bool) (*Error, int) {
+	for deep, err := range e.All() {
+		if pred(err) {
+			return err, deep
+		}
+	}
+	return nil, -1
+}
+
+// FindKind returns the first error in the chain with the kind and its
+// deep, or nil and -1 if there isn't one.
+func (e *Error) FindKind(kind Kind) (*Error, int) {
+	return e.FindFunc(func(err *Error) bool {
+		return err.kind == kind
+	})
+}
+
+// FindRegexp returns the first error in the chain whose message, like
+// Human, matches re and its deep, or nil and -1 if there isn't one.
+func (e *Error) FindRegexp(re *regexp.Regexp) (*Error, int) {
+	return e.FindFunc(func(err *Error) bool {
+		return re.MatchString(err.formatError())
+	})
+}
+
+// FindType returns the first error wrapped by an error of the chain of e
+// that has the type T, as errors.As finds it, the error of the chain and
+// its deep. If there isn't one, or if T isn't an interface or an error, it
+// returns the zero value, nil and -1.
+func FindType[T any](e *Error) (T, *Error, int) {
+	var target T
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Interface && !t.Implements(reflect.TypeFor[error]()) {
+		return target, nil, -1
+	}
+	for deep, err := range e.All() {
+		switch err.err.(type) {
+		case causes, *Error:
+			continue
+		}
+		if errors.As(err.err, &target) {
+			return target, err, deep
+		}
+	}
+	return target, nil, -1
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"io/fs"
+	"os"
+	"reflect"
+	"regexp"
+	"syscall"
+	"testing"
+)
+
+func findChain() *Error {
+	path := &fs.PathError{Op: "open", Path: "/foo", Err: syscall.ENOENT}
+	branch := New(path).(*Error).Push(New("user %v", "foo", ErrTestKind))
+	return New(ErrDummy).(*Error).Push(Merge(ErrSilly, branch)).Push(ErrStr)
+}
+
+func TestAll(t *testing.T) {
+	err := findChain()
+	var msgs []string
+	var deeps []int
+	for deep, e := range err.All() {
+		msgs = append(msgs, e.Human())
+		deeps = append(deeps, deep)
+	}
+	want := []string{ErrStr, ErrSilly.Error() + "; user foo", ErrSilly.Error(), "user foo", "open /foo: no such file or directory", ErrDummy.Error()}
+	if len(msgs) != len(want) {
+		t.Fatal("wrong errors", msgs)
+	}
+	for i := range want {
+		if msgs[i] != want[i] {
+			t.Fatal("wrong error", i, msgs[i])
+		}
+	}
+	for i, deep := range []int{0, 1, 2, 2, 3, 2} {
+		if deeps[i] != deep {
+			t.Fatal("wrong deep", i, deeps)
+		}
+	}
+	n := 0
+	for range err.All() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Fatal("break failed")
+	}
+	for range (*Error)(nil).All() {
+		t.Fatal("nil has errors")
+	}
+}
+
+func TestFindFunc(t *testing.T) {
+	err := findChain()
+	link, deep := err.FindKind(ErrTestKind)
+	if link == nil || link.Human() != "user foo" || deep != 2 {
+		t.Fatal("FindKind failed", deep)
+	}
+	if link, deep := err.FindKind(ErrInvalidType); link != nil || deep != -1 {
+		t.Fatal("kind found")
+	}
+	link, deep = err.FindFunc(func(e *Error) bool { return len(e.Arguments()) > 0 })
+	if link == nil || link.Human() != "user foo" || deep != 2 {
+		t.Fatal("FindFunc failed", deep)
+	}
+	link, deep = err.FindRegexp(regexp.MustCompile(`^open /\w+:`))
+	if link == nil || deep != 3 {
+		t.Fatal("FindRegexp failed", deep)
+	}
+	if link, deep := err.FindRegexp(regexp.MustCompile(`^user bar`)); link != nil || deep != -1 {
+		t.Fatal("regexp matched")
+	}
+}
+
+func TestFindType(t *testing.T) {
+	err := findChain()
+	path, link, deep := FindType[*fs.PathError](err)
+	if path == nil || path.Path != "/foo" || link == nil || deep != 3 {
+		t.Fatal("FindType failed", deep)
+	}
+	errno, _, deep := FindType[syscall.Errno](err)
+	if errno != syscall.ENOENT || deep != 3 {
+		t.Fatal("FindType failed", deep)
+	}
+	timeout, _, deep := FindType[interface{ Timeout() bool }](err)
+	if timeout == nil || deep != 3 {
+		t.Fatal("FindType failed", deep)
+	}
+	if le, link, deep := FindType[*os.LinkError](err); le != nil || link != nil || deep != -1 {
+		t.Fatal("type found")
+	}
+	if n, link, deep := FindType[int](err); n != 0 || link != nil || deep != -1 {
+		t.Fatal("FindType accepted int")
+	}
+}
+
+func TestAllWrapped(t *testing.T) {
+	path := &fs.PathError{Op: "open", Path: "/foo", Err: syscall.ENOENT}
+	inner := New(path).(*Error).Push(New("user %v", "foo", ErrTestKind))
+	err := (&Error{err: inner, next: New(ErrDummy).(*Error)}).Push(ErrStr)
+	var msgs []string
+	var deeps []int
+	for deep, e := range err.All() {
+		msgs = append(msgs, e.Human())
+		deeps = append(deeps, deep)
+	}
+	want := []string{ErrStr, inner.Error(), "user foo", "open /foo: no such file or directory", ErrDummy.Error()}
+	if !reflect.DeepEqual(msgs, want) || !reflect.DeepEqual(deeps, []int{0, 1, 2, 3, 2}) {
+		t.Fatal("wrong errors", msgs, deeps)
+	}
+	if link, deep := err.FindKind(ErrTestKind); link == nil || deep != 2 {
+		t.Fatal("FindKind failed", deep)
+	}
+	if _, link, deep := FindType[*fs.PathError](err); link == nil || link.Human() != path.Error() || deep != 3 {
+		t.Fatal("FindType failed", deep)
+	}
+}
@@ -1,10 +1,19 @@
 module github.com/fcavani/e
 
+go 1.23
+
 require (
 	github.com/fcavani/types v0.0.0-20190107200943-31b369769a8b
+	golang.org/x/tools v0.30.0
+	gopkg.in/vmihailenco/msgpack.v2 v2.9.1
+)
+
+require (
+	github.com/golang/protobuf v1.2.0 // indirect
 	github.com/kr/pretty v0.1.0 // indirect
-	golang.org/x/sync v0.0.0-20181221193216-37e7f081c4d4 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/net v0.35.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
 	google.golang.org/appengine v1.4.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
-	gopkg.in/vmihailenco/msgpack.v2 v2.9.1
 )
@@ -0,0 +1,110 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// HandlerFunc is an HTTP handler that returns an error. It is served by a
+// Handler with the default logger.
+type HandlerFunc func(http.ResponseWriter, *http.Request) error
+
+// ServeHTTP implements http.Handler, see Handler.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(&Handler{Handler: f}).ServeHTTP(w, r)
+}
+
+// Handler serves a HandlerFunc. The errors returned and the panics of the
+// handler are logged with their Trace and sent to the client as a problem,
+// see WriteProblem, with the status of their kind and only the message of
+// the error. If the handler already wrote the header the error is only
+// logged.
+type Handler struct {
+	Handler HandlerFunc
+	// Logger logs the errors, if nil slog.Default is used.
+	Logger *slog.Logger
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rw := &responseWriter{ResponseWriter: w}
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		if rec == http.ErrAbortHandler {
+			panic(rec)
+		}
+		h.fail(rw, r, newPanic(rec, 2))
+	}()
+	err := h.Handler(rw, r)
+	if err != nil {
+		h.fail(rw, r, err)
+	}
+}
+
+func (h *Handler) fail(w *responseWriter, r *http.Request, err error) {
+	p := NewProblem(err)
+	logger := h.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		e = newError(err, 2).(*Error)
+	}
+	logger.ErrorContext(r.Context(), "request failed",
+		slog.String("method", r.Method),
+		slog.String("url", r.URL.String()),
+		slog.Int("status", p.Status),
+		slog.String("instance", p.Instance),
+		slog.String("trace", e.Trace()),
+	)
+	if w.wrote {
+		return
+	}
+	_ = p.write(w)
+}
+
+// Middleware returns a middleware that serves the panics of the next
+// handler like Handler serves the errors.
+func Middleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return &Handler{
+			Handler: func(w http.ResponseWriter, r *http.Request) error {
+				next.ServeHTTP(w, r)
+				return nil
+			},
+			Logger: logger,
+		}
+	}
+}
+
+// responseWriter records if the header was written.
+type responseWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped http.ResponseWriter, it is used by
+// http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
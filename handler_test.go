@@ -0,0 +1,150 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testLogger() (*slog.Logger, *bytes.Buffer) {
+	buf := bytes.NewBuffer([]byte{})
+	return slog.New(slog.NewJSONHandler(buf, nil)), buf
+}
+
+func TestHandler(t *testing.T) {
+	logger, buf := testLogger()
+	h := &Handler{
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			return New(ErrDummy).(*Error).Push(New("user %v", "foo", ErrNotFound))
+		},
+		Logger: logger,
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/users/foo", nil))
+	var p Problem
+	if er := json.Unmarshal(w.Body.Bytes(), &p); er != nil {
+		t.Fatal(er)
+	}
+	if w.Code != http.StatusNotFound || p.Status != http.StatusNotFound || p.Kind != ErrNotFound {
+		t.Fatal("wrong status", w.Code, p.Status)
+	}
+	if p.Detail != "user foo" || strings.Contains(w.Body.String(), "error_test") || strings.Contains(w.Body.String(), ErrDummy.Error()) {
+		t.Fatal("wrong detail", w.Body.String())
+	}
+	var entry map[string]interface{}
+	if er := json.Unmarshal(buf.Bytes(), &entry); er != nil {
+		t.Fatal(er)
+	}
+	trace, _ := entry["trace"].(string)
+	if !strings.Contains(trace, ErrDummy.Error()) || !strings.Contains(trace, "handler_test.go") {
+		t.Fatal("wrong trace", trace)
+	}
+	if entry["instance"] != p.Instance || entry["url"] != "/users/foo" || entry["status"] != float64(404) {
+		t.Fatal("wrong log", entry)
+	}
+}
+
+func TestHandlerFunc(t *testing.T) {
+	var h http.Handler = HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+		t.Fatal("wrong response", w.Code)
+	}
+}
+
+func TestHandlerPanic(t *testing.T) {
+	logger, buf := testLogger()
+	h := &Handler{
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			panic("boom")
+		},
+		Logger: logger,
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusInternalServerError || w.Header().Get("Content-Type") != ProblemContentType {
+		t.Fatal("wrong response", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "boom") {
+		t.Fatal("panic sent to the client", w.Body.String())
+	}
+	if !strings.Contains(buf.String(), "panic: boom") {
+		t.Fatal("panic not logged", buf.String())
+	}
+	h.Handler = func(w http.ResponseWriter, r *http.Request) error {
+		panic(http.ErrAbortHandler)
+	}
+	func() {
+		defer func() {
+			if recover() != http.ErrAbortHandler {
+				t.Fatal("ErrAbortHandler was recovered")
+			}
+		}()
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}()
+}
+
+func TestHandlerWroteHeader(t *testing.T) {
+	logger, buf := testLogger()
+	h := &Handler{
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			_, _ = w.Write([]byte("partial"))
+			return New(ErrDummy)
+		},
+		Logger: logger,
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "partial" {
+		t.Fatal("response changed", w.Code, w.Body.String())
+	}
+	if !strings.Contains(buf.String(), ErrDummy.Error()) {
+		t.Fatal("error not logged")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	logger, buf := testLogger()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic(New(ErrInvalidType))
+	})
+	srv := httptest.NewServer(Middleware(logger)(mux))
+	defer srv.Close()
+	resp, er := http.Get(srv.URL + "/ok")
+	if er != nil {
+		t.Fatal(er)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || buf.Len() != 0 {
+		t.Fatal("wrong response", resp.StatusCode)
+	}
+	resp, er = http.Get(srv.URL + "/panic")
+	if er != nil {
+		t.Fatal(er)
+	}
+	defer resp.Body.Close()
+	err, er := FromProblem(resp)
+	if er != nil {
+		t.Fatal(er)
+	}
+	if resp.StatusCode != http.StatusInternalServerError || err.Human() != http.StatusText(http.StatusInternalServerError) {
+		t.Fatal("wrong response", resp.StatusCode, err.Human())
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+// Package format checks the format strings of the fmt package. It is shared
+// by the strict mode of package e and by its vet analyzer.
+package format
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// verbs are the verbs accepted by fmt.Sprintf.
+const verbs = "bcdeEfFgGoOpqstTUvxX"
+
+// Check parses format like fmt.Sprintf and returns an error if a verb is
+// invalid or if the verbs don't use exactly nargs arguments. If the
+// arguments are reordered by explicit indexes the arguments not used by
+// any verb aren't reported, like fmt does.
+func Check(format string, nargs int) error {
+	c := checker{format: format, nargs: nargs}
+	return c.parse()
+}
+
+// Directives returns the directive that formats each of the nargs
+// arguments, like "%08.3f", without the explicit indexes and the widths and
+// precisions taken from arguments. The arguments used as widths or
+// precisions have "%v", the arguments not used by any verb, or after an
+// error in format, have an empty string.
+func Directives(format string, nargs int) []string {
+	c := checker{format: format, nargs: nargs, directives: make([]string, nargs)}
+	_ = c.parse()
+	return c.directives
+}
+
+// Count returns the number of arguments used by the verbs of format, the
+// widths and the precisions, the position of the last one if they are
+// reordered by explicit indexes. Only the arguments before an error in
+// format are counted.
+func Count(format string) int {
+	c := checker{format: format, nargs: math.MaxInt}
+	_ = c.parse()
+	return c.used
+}
+
+func (c *checker) parse() error {
+	format := c.format
+	for c.i < len(format) {
+		if format[c.i] != '%' {
+			c.i++
+			continue
+		}
+		c.start = c.i
+		c.i++
+		for c.i < len(format) && strings.IndexByte("+-# 0", format[c.i]) >= 0 {
+			c.i++
+		}
+		err := c.number()
+		if err != nil {
+			return err
+		}
+		if c.i < len(format) && format[c.i] == '.' {
+			c.i++
+			err = c.number()
+			if err != nil {
+				return err
+			}
+		}
+		err = c.index()
+		if err != nil {
+			return err
+		}
+		if c.i >= len(format) {
+			return fmt.Errorf("missing verb at the end of %q", format)
+		}
+		verb, size := utf8.DecodeRuneInString(format[c.i:])
+		c.i += size
+		if verb == '%' {
+			continue
+		}
+		if !strings.ContainsRune(verbs, verb) {
+			return fmt.Errorf("bad verb %%%c in %q", verb, format)
+		}
+		err = c.use("%" + string(verb))
+		if err != nil {
+			return err
+		}
+	}
+	if !c.reordered && c.arg < c.nargs {
+		return fmt.Errorf("%q uses %d of the %d arguments", format, c.arg, c.nargs)
+	}
+	return nil
+}
+
+type checker struct {
+	format    string
+	nargs     int
+	i         int
+	arg       int
+	reordered bool
+	// start is the position of the directive being parsed.
+	start int
+	// directives, if not nil, records the directive of each argument.
+	directives []string
+	// used is the number of arguments used.
+	used int
+}
+
+// index parses an explicit argument index, like [2].
+func (c *checker) index() error {
+	if c.i >= len(c.format) || c.format[c.i] != '[' {
+		return nil
+	}
+	end := strings.IndexByte(c.format[c.i:], ']')
+	if end < 0 {
+		return fmt.Errorf("bad argument index in %q", c.format)
+	}
+	var n int
+	_, err := fmt.Sscanf(c.format[c.i+1:c.i+end], "%d", &n)
+	if err != nil || n < 1 {
+		return fmt.Errorf("bad argument index in %q", c.format)
+	}
+	c.i += end + 1
+	c.arg = n - 1
+	c.reordered = true
+	return nil
+}
+
+// number parses a width or a precision, that may be taken from an
+// argument with *.
+func (c *checker) number() error {
+	err := c.index()
+	if err != nil {
+		return err
+	}
+	if c.i < len(c.format) && c.format[c.i] == '*' {
+		c.i++
+		return c.use("*")
+	}
+	for c.i < len(c.format) && c.format[c.i] >= '0' && c.format[c.i] <= '9' {
+		c.i++
+	}
+	return nil
+}
+
+func (c *checker) use(what string) error {
+	if c.arg >= c.nargs {
+		return fmt.Errorf("missing argument for %v in %q", what, c.format)
+	}
+	if c.directives != nil {
+		if what == "*" {
+			c.directives[c.arg] = "%v"
+		} else {
+			c.directives[c.arg] = c.directive()
+		}
+	}
+	c.arg++
+	c.used = max(c.used, c.arg)
+	return nil
+}
+
+// directive returns the directive that ends at the current position without
+// the explicit indexes and the *.
+func (c *checker) directive() string {
+	var b strings.Builder
+	d := c.format[c.start:c.i]
+	for i := 0; i < len(d); i++ {
+		switch d[i] {
+		case '[':
+			if end := strings.IndexByte(d[i:], ']'); end >= 0 {
+				i += end
+			}
+		case '*':
+		default:
+			b.WriteByte(d[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package format
+
+import "testing"
+
+func TestCheck(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		nargs  int
+		ok     bool
+	}{
+		{"no verbs", 0, true},
+		{"100%%", 0, true},
+		{"%v and %d", 2, true},
+		{"%+v %#x %-8s %08.3f", 4, true},
+		{"%*d", 2, true},
+		{"%.*f", 2, true},
+		{"%[2]v %[1]v", 2, true},
+		{"%[2]v", 2, true},
+		{"%v", 0, false},
+		{"%v %v", 1, false},
+		{"%v", 2, false},
+		{"no verbs", 1, false},
+		{"%z", 1, false},
+		{"%w", 1, false},
+		{"%*d", 1, false},
+		{"%[3]v", 2, false},
+		{"%[0]v", 1, false},
+		{"%[x", 1, false},
+		{"trailing %", 0, false},
+	} {
+		err := Check(test.format, test.nargs)
+		if (err == nil) != test.ok {
+			t.Fatal(test.format, test.nargs, err)
+		}
+	}
+}
+
+func TestDirectives(t *testing.T) {
+	for _, test := range []struct {
+		format     string
+		nargs      int
+		directives []string
+	}{
+		{"no verbs", 0, []string{}},
+		{"%v and %d", 2, []string{"%v", "%d"}},
+		{"%+v %#x %-8s %08.3f", 4, []string{"%+v", "%#x", "%-8s", "%08.3f"}},
+		{"%*d", 2, []string{"%v", "%d"}},
+		{"%[2]x %[1]T", 2, []string{"%T", "%x"}},
+		{"%v", 2, []string{"%v", ""}},
+		{"%v %z %v", 2, []string{"%v", ""}},
+	} {
+		d := Directives(test.format, test.nargs)
+		if len(d) != len(test.directives) {
+			t.Fatal(test.format, d)
+		}
+		for i := range d {
+			if d[i] != test.directives[i] {
+				t.Fatal(test.format, d)
+			}
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	for format, n := range map[string]int{
+		"no verbs":      0,
+		"100%% %v":      1,
+		"%v and %d":     2,
+		"%*d %.*f":      4,
+		"%[3]v %[1]v":   3,
+		"%v %z %v":      1,
+		"trailing % %v": 0,
+	} {
+		if c := Count(format); c != n {
+			t.Fatal(format, c)
+		}
+	}
+}
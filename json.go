@@ -0,0 +1,300 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fcavani/e/internal/format"
+)
+
+// jsonError is the JSON representation of one error of the chain.
+type jsonError struct {
+	Message   string    `json:"message"`
+	Template  string    `json:"template,omitempty"`
+	Args      []jsonArg `json:"args,omitempty"`
+	Debug     bool      `json:"debug"`
+	Pkg       string    `json:"pkg,omitempty"`
+	Import    string    `json:"import,omitempty"`
+	Package   string    `json:"package,omitempty"`
+	Receiver  string    `json:"receiver,omitempty"`
+	Function  string    `json:"function,omitempty"`
+	Closure   []int     `json:"closure,omitempty"`
+	File      string    `json:"file,omitempty"`
+	Line      int       `json:"line,omitempty"`
+	Stack     []Frame   `json:"stack,omitempty"`
+	Kind      Kind      `json:"kind,omitempty"`
+	Class     string    `json:"class,omitempty"`
+	Fields    []jsonArg `json:"fields,omitempty"`
+	Time      string    `json:"time,omitempty"`
+	Monotonic int64     `json:"monotonic,omitempty"`
+	Goroutine uint64    `json:"goroutine,omitempty"`
+	Err       *Error    `json:"err,omitempty"`
+	Merged    []*Error  `json:"merged,omitempty"`
+	Next      *Error    `json:"next,omitempty"`
+}
+
+// jsonArg is one argument or field of the error and the name of its type.
+// Key is empty for arguments.
+type jsonArg struct {
+	Key   string          `json:"key,omitempty"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// jsonArgTypes are the types of the arguments that are restored by
+// UnmarshalJSON. Others are sent like the gob and msgpack codecs send the
+// types that aren't registered, see Register.
+var jsonArgTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, v := range []interface{}{
+		false, "",
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+		float32(0), float64(0),
+		[]byte(nil), GoError(""), formatted(""),
+	} {
+		t := reflect.TypeOf(v)
+		jsonArgTypes[t.String()] = t
+	}
+}
+
+// MarshalJSON implements json.Marshaler. Each error of the chain is an
+// object with this fields:
+//
+//	message   the formatted message, like Human.
+//	template  the error message before formatting.
+//	args      the arguments of the template, each one is an object with
+//	          the type name in "type" and the value in "value". The
+//	          arguments of other types are sent as the basic type of
+//	          their kind or already formatted.
+//	debug     true if the debug information is present.
+//	pkg       the function where the error occurred.
+//	import    the import path of the package of the function.
+//	package   the name of the package of the function.
+//	receiver  the receiver of the method where the error occurred.
+//	function  the function or the method where the error occurred.
+//	closure   the indexes of the nested closures, see Symbol.
+//	file      the file where the error occurred.
+//	line      the line where the error occurred.
+//	stack     the stack where the error occurred, a list of objects with
+//	          the fields function, file and line.
+//	kind      the kind of the error.
+//	class     the class of the error: retryable, temporary or permanent.
+//	fields    the fields of the error, objects like the arguments with the
+//	          name of the field in "key".
+//	time      when the error was created, in RFC 3339 format.
+//	monotonic the monotonic clock when the error was created, in
+//	          nanoseconds, see Monotonic.
+//	goroutine the ID of the goroutine that created the error.
+//	err       the *Error wrapped by this error, if any, instead of template.
+//	merged    the list of errors joined by Merge, instead of template.
+//	next      the next error in the chain.
+//
+// Empty fields are omitted.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	je := jsonError{
+		Message: e.Human(),
+		Debug:   e.debugInfo,
+		Kind:    e.kind,
+		Class:   e.class.String(),
+		Next:    e.next,
+	}
+	if !e.time.IsZero() {
+		je.Time = e.time.Format(time.RFC3339Nano)
+	}
+	je.Monotonic = int64(e.mono)
+	je.Goroutine = e.goroutine
+	switch v := e.err.(type) {
+	case *Error:
+		je.Err = v
+	case causes:
+		je.Merged = v
+	case error:
+		je.Template = v.Error()
+	default:
+		panic("type not supported")
+	}
+	directives := format.Directives(je.Template, len(e.args))
+	for i, arg := range e.args {
+		ja, err := marshalJSONArg(arg, directives[i])
+		if err != nil {
+			return nil, err
+		}
+		je.Args = append(je.Args, ja)
+	}
+	for _, f := range e.fields {
+		ja, err := marshalJSONArg(f.Value, "")
+		if err != nil {
+			return nil, err
+		}
+		ja.Key = f.Key
+		je.Fields = append(je.Fields, ja)
+	}
+	if e.debugInfo {
+		caller := e.caller()
+		je.Pkg = caller.Function
+		symbol := caller.Symbol()
+		je.Import = symbol.ImportPath
+		je.Package = symbol.Package
+		je.Receiver = symbol.Receiver
+		je.Function = symbol.Function
+		je.Closure = symbol.Closure
+		je.File = e.file()
+		je.Line = caller.Line
+		je.Stack = e.StackTrace()
+	}
+	return json.Marshal(je)
+}
+
+// marshalJSONArg marshals an argument formatted by directive, or a field if
+// directive is empty.
+func marshalJSONArg(arg interface{}, directive string) (jsonArg, error) {
+	var ja jsonArg
+	d := directive
+	if d == "" {
+		d = "%v"
+	}
+	v, ok := sendable(arg, d, isJSONArgType)
+	switch {
+	case !ok && directive == "":
+		v = fmt.Sprint(arg)
+	case !ok:
+		v = formatted(fmt.Sprintf(d, arg))
+	}
+	if v != nil {
+		ja.Type = reflect.TypeOf(v).String()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ja, err
+	}
+	ja.Value = b
+	return ja, nil
+}
+
+func isJSONArgType(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	return jsonArgTypes[t.String()] == t
+}
+
+// unmarshal returns the value of the argument, nil values have no type.
+func (ja jsonArg) unmarshal() (interface{}, error) {
+	if ja.Type == "" {
+		return nil, nil
+	}
+	t, found := jsonArgTypes[ja.Type]
+	if !found {
+		return nil, fmt.Errorf("invalid argument type %v", ja.Type)
+	}
+	v := reflect.New(t)
+	err := json.Unmarshal(ja.Value, v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
+
+// checkJSONLinks returns a protocol error if err, merged or next are null
+// or if there is a null in the merged errors.
+func checkJSONLinks(data []byte, merged []*Error) error {
+	var links struct {
+		Err    json.RawMessage `json:"err"`
+		Merged json.RawMessage `json:"merged"`
+		Next   json.RawMessage `json:"next"`
+	}
+	err := json.Unmarshal(data, &links)
+	if err != nil {
+		return err
+	}
+	for _, raw := range []json.RawMessage{links.Err, links.Merged, links.Next} {
+		if string(raw) == "null" {
+			return errors.New("protocol error")
+		}
+	}
+	for _, e := range merged {
+		if e == nil {
+			return errors.New("protocol error")
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. See MarshalJSON for the
+// schema.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var je jsonError
+	err := json.Unmarshal(data, &je)
+	if err != nil {
+		return err
+	}
+	err = checkJSONLinks(data, je.Merged)
+	if err != nil {
+		return err
+	}
+	switch {
+	case je.Err != nil:
+		e.err = je.Err
+	case je.Merged != nil:
+		e.err = causes(je.Merged)
+	case je.Kind != "" && je.Template == string(je.Kind):
+		e.err = je.Kind
+	default:
+		e.err = GoError(je.Template)
+	}
+	e.args = nil
+	for _, ja := range je.Args {
+		v, err := ja.unmarshal()
+		if err != nil {
+			return err
+		}
+		e.args = append(e.args, v)
+	}
+	if len(e.args) > 0 && fmt.Sprintf(je.Template, e.args...) != je.Message {
+		// The arguments weren't rebuilt, the message is kept instead.
+		e.err = GoError(strings.ReplaceAll(je.Message, "%", "%%"))
+		e.args = nil
+	}
+	e.fields = nil
+	for _, ja := range je.Fields {
+		v, err := ja.unmarshal()
+		if err != nil {
+			return err
+		}
+		e.fields = append(e.fields, Field{Key: ja.Key, Value: v})
+	}
+	e.debugInfo = je.Debug
+	e.stack = nil
+	e.frames = je.Stack
+	if len(e.frames) == 0 && e.debugInfo {
+		e.frames = []Frame{{Function: je.Pkg, File: je.File, Line: je.Line}}
+	}
+	e.kind = je.Kind
+	e.class, err = parseClass(je.Class)
+	if err != nil {
+		return err
+	}
+	e.time = time.Time{}
+	if je.Time != "" {
+		e.time, err = time.Parse(time.RFC3339Nano, je.Time)
+		if err != nil {
+			return err
+		}
+	}
+	e.mono = time.Duration(je.Monotonic)
+	e.goroutine = je.Goroutine
+	e.next = je.Next
+	return nil
+}
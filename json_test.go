@@ -0,0 +1,132 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type unknownArg struct {
+	A int
+}
+
+func TestJSON(t *testing.T) {
+	err := New("value %d, ratio %.2f, name %q, ok %v, other %v", 42, 0.5, "foo", true, unknownArg{A: 1}).(*Error)
+	err = Merge(err.Push(ErrStr), ErrSilly).(*Error).Push(ErrAnother)
+	b, er := json.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var jerr *Error
+	if er := json.Unmarshal(b, &jerr); er != nil {
+		t.Fatal(er)
+	}
+	if jerr.Trace() != err.Trace() {
+		t.Fatalf("Trace differ:\n%v\n%v", jerr.Trace(), err.Trace())
+	}
+	for _, target := range []interface{}{ErrAnother, ErrSilly, ErrStr, "value %d, ratio %.2f, name %q, ok %v, other %v"} {
+		if jerr.Find(target) != err.Find(target) {
+			t.Fatal("Find differ", target)
+		}
+	}
+	if !jerr.Equal(err) || !err.Equal(jerr) {
+		t.Fatal("not equal")
+	}
+	args := jerr.next.Causes()[0].next.Arguments()
+	if !reflect.DeepEqual(args, []interface{}{42, 0.5, "foo", true, formatted("{1}")}) {
+		t.Fatalf("wrong args: %#v", args)
+	}
+	if !reflect.DeepEqual(jerr.StackTrace(), err.StackTrace()) {
+		t.Fatal("stack differ")
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	withConfig(t, Config{})
+	err := New("value %d", 42).(*Error).Push(ErrStr)
+	b, er := json.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	const want = `{"message":"string error","template":"string error","debug":false,"next":{"message":"value 42","template":"value %d","args":[{"type":"int","value":42}],"debug":false}}`
+	if string(b) != want {
+		t.Fatal("wrong json:", string(b))
+	}
+	var jerr *Error
+	if er := json.Unmarshal([]byte(`{"template":"x","args":[{"type":"chan","value":1}]}`), &jerr); er == nil {
+		t.Fatal("invalid type accepted")
+	}
+}
+
+type jsonID int
+
+type jsonStringer int
+
+func (s jsonStringer) String() string {
+	return "stringer " + strconv.Itoa(int(s))
+}
+
+func TestJSONArgs(t *testing.T) {
+	withConfig(t, Config{})
+	for _, err := range []*Error{
+		New("took %d for %x id %d", time.Second, []byte{1, 2}, jsonID(5)).(*Error),
+		New("took %v, %T, %#v, %s, %d, %v", time.Second, jsonID(5), jsonID(6), jsonStringer(1), jsonStringer(2), &unknownArg{A: 3}).(*Error),
+		New("%v %d %s, %x", nil, nil, GoError("go error"), GoError("hex")).(*Error),
+		New("100%% %v", unknownArg{A: 1}).(*Error),
+	} {
+		b, er := json.Marshal(err)
+		if er != nil {
+			t.Fatal(er)
+		}
+		var jerr *Error
+		if er := json.Unmarshal(b, &jerr); er != nil {
+			t.Fatal(er)
+		}
+		if jerr.Trace() != err.Trace() {
+			t.Fatalf("Trace differ:\n%v\n%v", jerr.Trace(), err.Trace())
+		}
+	}
+	err := New("took %d for %x", time.Second, []byte{1, 2}).(*Error)
+	b, er := json.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var jerr *Error
+	if er := json.Unmarshal(b, &jerr); er != nil {
+		t.Fatal(er)
+	}
+	if !reflect.DeepEqual(jerr.Arguments(), []interface{}{int64(time.Second), []byte{1, 2}}) {
+		t.Fatalf("wrong args: %#v", jerr.Arguments())
+	}
+	// The message is kept if the arguments can't be rebuilt.
+	if er := json.Unmarshal([]byte(`{"message":"100% 5s","template":"100%% %v","args":[{"type":"int","value":5}]}`), &jerr); er != nil {
+		t.Fatal(er)
+	}
+	if jerr.Human() != "100% 5s" || jerr.Arguments() != nil {
+		t.Fatal("wrong message", jerr.Human())
+	}
+}
+
+func TestJSONNullLinks(t *testing.T) {
+	for _, data := range []string{
+		`{"merged":[null]}`,
+		`{"merged":[{"template":"a"},null]}`,
+		`{"merged":null}`,
+		`{"err":null}`,
+		`{"template":"a","next":null}`,
+		`{"template":"a","next":{"merged":[null]}}`,
+	} {
+		var jerr *Error
+		if er := json.Unmarshal([]byte(data), &jerr); er == nil || !strings.Contains(er.Error(), "protocol error") {
+			t.Fatal("null accepted", data, er)
+		}
+	}
+}
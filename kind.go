@@ -0,0 +1,101 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"errors"
+
+	"github.com/fcavani/e/internal/format"
+)
+
+// Kind classifies the errors. A Kind is an error, an *Error created from
+// a Kind has that kind, and an option: passed to New among the arguments,
+// after the arguments of the verbs of the template, it sets the kind of the
+// new error.
+type Kind string
+
+func (k Kind) Error() string {
+	return string(k)
+}
+
+func (k Kind) apply(e *Error) {
+	e.kind = k
+}
+
+// Option sets a property of an error when it is created. See New.
+type Option interface {
+	apply(e *Error)
+}
+
+// splitOptions removes the options from the arguments of template. A Kind
+// is an argument, and not an option, if template has a verb left for it, so
+// New("bad input: %v", ErrEmptyString) formats the kind.
+func splitOptions(template string, a []interface{}) ([]interface{}, []Option) {
+	var opts []Option
+	var args []interface{}
+	verbs := -1
+	for i, arg := range a {
+		opt, ok := arg.(Option)
+		if _, kind := arg.(Kind); ok && kind {
+			if verbs < 0 {
+				verbs = format.Count(template)
+			}
+			ok = i-len(opts) >= verbs
+		}
+		if !ok {
+			if opts != nil {
+				args = append(args, arg)
+			}
+			continue
+		}
+		if opts == nil {
+			args = append(make([]interface{}, 0, len(a)), a[:i]...)
+		}
+		opts = append(opts, opt)
+	}
+	if opts == nil {
+		return a, nil
+	}
+	if len(args) == 0 {
+		args = nil
+	}
+	return args, opts
+}
+
+func applyOptions(e *Error, opts []Option) {
+	for _, opt := range opts {
+		opt.apply(e)
+	}
+}
+
+// Kind returns the kind of this error of the chain, use KindOf to find the
+// kind of the chain.
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+// KindOf returns the first kind found in the chain of err, including the
+// merged errors, or an empty Kind if none is found.
+func KindOf(err error) Kind {
+	var kind Kind
+	var e *Error
+	if errors.As(err, &e) {
+		e.walk(0, func(err *Error, deep int) bool {
+			if err.kind != "" {
+				kind = err.kind
+				return false
+			}
+			return true
+		})
+		if kind != "" {
+			return kind
+		}
+	}
+	if errors.As(err, &kind) {
+		return kind
+	}
+	return ""
+}
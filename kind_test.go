@@ -0,0 +1,118 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+)
+
+const ErrTestKind Kind = "test kind"
+
+func TestKind(t *testing.T) {
+	err := New(ErrInvalidType).(*Error)
+	if err.Kind() != ErrInvalidType || KindOf(err) != ErrInvalidType {
+		t.Fatal("wrong kind", err.Kind())
+	}
+	if !errors.Is(err, ErrInvalidType) || !err.Equal(ErrInvalidType) {
+		t.Fatal("Is failed")
+	}
+	err = New("value %v, %v", 1, 2, ErrTestKind).(*Error)
+	if err.Kind() != ErrTestKind {
+		t.Fatal("wrong kind", err.Kind())
+	}
+	if !reflect.DeepEqual(err.Arguments(), []interface{}{1, 2}) || err.Human() != "value 1, 2" {
+		t.Fatal("wrong arguments", err.Arguments())
+	}
+	// A kind with a verb left for it is an argument.
+	err = New("bad input: %v", ErrEmptyString).(*Error)
+	if err.Human() != "bad input: empty string" || err.Kind() != "" {
+		t.Fatal("kind taken as option", err.Human())
+	}
+	err = New("value %v: %v, %v", 1, ErrEmptyString, 2, ErrTestKind).(*Error)
+	if err.Human() != "value 1: empty string, 2" || err.Kind() != ErrTestKind {
+		t.Fatal("wrong kind", err.Human(), err.Kind())
+	}
+	if err := New("%[2]v %[1]v", 1, ErrEmptyString).(*Error); err.Human() != "empty string 1" {
+		t.Fatal("kind taken as option", err.Human())
+	}
+	if New(ErrDummy, ErrTestKind).(*Error).Arguments() != nil {
+		t.Fatal("arguments aren't nil")
+	}
+	if KindOf(New(ErrDummy)) != "" || KindOf(io.EOF) != "" || KindOf(nil) != "" {
+		t.Fatal("kind found")
+	}
+	sentinel := New(ErrDummy).(*Error)
+	kinded := New(sentinel, ErrTestKind).(*Error)
+	if kinded.Kind() != ErrTestKind || sentinel.Kind() != "" {
+		t.Fatal("wrong kind")
+	}
+	if NewN(ErrDummy, 0, ErrTestKind).(*Error).Kind() != ErrTestKind {
+		t.Fatal("NewN failed")
+	}
+}
+
+func TestKindChain(t *testing.T) {
+	err := New("value %v", 1, ErrTestKind).(*Error).Push(ErrStr).Push(ErrSilly)
+	if err.Kind() != "" || KindOf(err) != ErrTestKind {
+		t.Fatal("wrong kind")
+	}
+	if KindOf(err.Push(ErrInvalidLength)) != ErrInvalidLength {
+		t.Fatal("wrong kind after push")
+	}
+	if KindOf(Forward(err)) != ErrTestKind {
+		t.Fatal("wrong kind after forward")
+	}
+	if Forward(err.next.next).(*Error).Kind() != ErrTestKind {
+		t.Fatal("forward didn't copy the kind")
+	}
+	if KindOf(Copy(err)) != ErrTestKind {
+		t.Fatal("wrong kind after copy")
+	}
+	if KindOf(Merge(ErrDummy, err)) != ErrTestKind {
+		t.Fatal("wrong kind after merge")
+	}
+	if KindOf(fmt.Errorf("wrapped: %w", err)) != ErrTestKind {
+		t.Fatal("wrong kind of wrapped error")
+	}
+	if KindOf(fmt.Errorf("wrapped: %w", ErrEmptyString)) != ErrEmptyString {
+		t.Fatal("wrong kind of wrapped kind")
+	}
+	for name, rt := range map[string]func(*testing.T, *Error) *Error{"gob": gobRoundTrip, "msgpack": msgpackRoundTrip} {
+		derr := rt(t, err.Push(ErrInvalidInterface))
+		if derr.Kind() != ErrInvalidInterface || KindOf(derr.next) != ErrTestKind {
+			t.Fatal(name, "wrong kind")
+		}
+		if !errors.Is(derr, ErrInvalidInterface) {
+			t.Fatal(name, "Is failed")
+		}
+	}
+	b, er := json.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var jerr *Error
+	if er := json.Unmarshal(b, &jerr); er != nil {
+		t.Fatal(er)
+	}
+	if KindOf(jerr) != ErrTestKind || jerr.next.next.Kind() != ErrTestKind {
+		t.Fatal("json: wrong kind")
+	}
+	b, er = json.Marshal(New(ErrInvalidType))
+	if er != nil {
+		t.Fatal(er)
+	}
+	if er := json.Unmarshal(b, &jerr); er != nil {
+		t.Fatal(er)
+	}
+	if !errors.Is(jerr, ErrInvalidType) {
+		t.Fatal("json: Is failed")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// PanicValue is the cause of the errors made from recovered panics.
+type PanicValue struct {
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack of the goroutine that panicked, formatted like
+	// debug.Stack.
+	Stack []byte
+}
+
+func (p *PanicValue) Error() string {
+	return fmt.Sprint("panic: ", p.Value)
+}
+
+// Unwrap returns Value if it is an error.
+func (p *PanicValue) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// newPanic makes an *Error of kind ErrPanic from the recovered value rec.
+// The debug information points to the function that panicked.
+func newPanic(rec interface{}, level int) *Error {
+	err := newError(&PanicValue{Value: rec, Stack: debug.Stack()}, level+1).(*Error)
+	err.kind = ErrPanic
+	for i, pc := range err.stack {
+		if f := runtime.FuncForPC(pc - 1); f != nil && f.Name() == "runtime.gopanic" {
+			err.stack = err.stack[i+1:]
+			break
+		}
+	}
+	return err
+}
+
+// Recover recovers a panic and stores it in err as an *Error of kind
+// ErrPanic, with the value of the panic as its cause, see PanicValue. If
+// err already holds an error the panic is pushed on it. Recover must be
+// deferred directly:
+//
+//	func f() (err error) {
+//		defer e.Recover(&err)
+//		...
+//	}
+func Recover(err *error) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+	perr := newPanic(rec, 2)
+	if *err != nil {
+		*err = PushN(*err, perr, 1)
+		return
+	}
+	*err = perr
+}
+
+// Go runs fn in a new goroutine and sends the error it returns, or its
+// panic as Recover does, to the returned channel, that is closed after.
+func Go(fn func() error) <-chan error {
+	c := make(chan error, 1)
+	go func() {
+		defer close(c)
+		c <- run(fn)
+	}()
+	return c
+}
+
+func run(fn func() error) (err error) {
+	defer Recover(&err)
+	return fn()
+}
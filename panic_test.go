@@ -0,0 +1,91 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func panics(v interface{}) (err error) {
+	defer Recover(&err)
+	panic(v)
+}
+
+func TestRecover(t *testing.T) {
+	err := panics("boom")
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatal("not an *Error")
+	}
+	if e.Kind() != ErrPanic || e.Human() != "panic: boom" {
+		t.Fatal("wrong error", e.Kind(), e.Human())
+	}
+	var pv *PanicValue
+	if !errors.As(err, &pv) || pv.Value != "boom" {
+		t.Fatal("value lost")
+	}
+	if !strings.Contains(string(pv.Stack), "e.panics(") {
+		t.Fatal("wrong stack", string(pv.Stack))
+	}
+	if !strings.HasSuffix(e.Pkg(), ".panics") || !strings.Contains(e.Trace(), "panic_test.go") {
+		t.Fatal("wrong debug information", e.Pkg())
+	}
+	err = panics(io.EOF)
+	if !errors.Is(err, io.EOF) || KindOf(err) != ErrPanic {
+		t.Fatal("cause lost")
+	}
+	if panics(nil) == nil {
+		t.Fatal("panic(nil) not recovered")
+	}
+}
+
+func TestRecoverPackagePanic(t *testing.T) {
+	err := func() (err error) {
+		defer Recover(&err)
+		_ = PushN(42, ErrDummy, 0)
+		return nil
+	}()
+	if KindOf(err) != ErrPanic || !strings.Contains(err.(*Error).Human(), "invalid type") {
+		t.Fatal("wrong error", err)
+	}
+}
+
+func TestRecoverPush(t *testing.T) {
+	err := func() (err error) {
+		defer Recover(&err)
+		err = New(ErrDummy)
+		panic("boom")
+	}()
+	e := err.(*Error)
+	if e.Kind() != ErrPanic || e.next == nil || !e.next.Equal(ErrDummy) {
+		t.Fatal("error lost", e.Trace())
+	}
+	if func() (err error) {
+		defer Recover(&err)
+		return nil
+	}() != nil {
+		t.Fatal("error without panic")
+	}
+}
+
+func TestGo(t *testing.T) {
+	if err := <-Go(func() error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-Go(func() error { return New(ErrDummy) }); !errors.Is(err, ErrDummy) {
+		t.Fatal("wrong error", err)
+	}
+	c := Go(func() error { panic("boom") })
+	if err := <-c; KindOf(err) != ErrPanic {
+		t.Fatal("panic not recovered", err)
+	}
+	if _, ok := <-c; ok {
+		t.Fatal("channel not closed")
+	}
+}
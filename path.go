@@ -0,0 +1,107 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"path"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// PathMode sets how the paths of the source files are rendered.
+type PathMode uint8
+
+const (
+	// PathShort renders the directory and the name of the file, like
+	// "storage/db.go". It is the default.
+	PathShort PathMode = iota
+	// PathModule renders the path relative to the root of the module of
+	// the program, found with debug.ReadBuildInfo, like "internal/storage/db.go".
+	// The files of other modules are rendered with the import path of
+	// their packages, like "github.com/user/lib/db.go".
+	PathModule
+	// PathTrim removes from the path the first prefix of TrimPrefix that
+	// matches it, like a GOPATH or the directory of a module.
+	PathTrim
+	// PathFull renders the full path, the codecs send the paths of the
+	// machine where the program was built.
+	PathFull
+	// PathBase renders only the name of the file.
+	PathBase
+)
+
+// mainModule returns the path of the main module and the import path of
+// the main package.
+var mainModule = sync.OnceValues(func() (string, string) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+	return bi.Main.Path, bi.Path
+})
+
+// shortFile keeps only the directory and the name of the file.
+func shortFile(file string) string {
+	s := strings.Split(file, "/")
+	l := len(s)
+	if l >= 2 {
+		return strings.Join(s[l-2:l], "/")
+	}
+	return s[0]
+}
+
+// modulePath returns the path of the file of the frame relative to the
+// root of the main module.
+func modulePath(f Frame) string {
+	pkg := f.Symbol().ImportPath
+	base := path.Base(f.File)
+	module, main := mainModule()
+	if pkg == "main" && !strings.HasSuffix(main, ".test") {
+		pkg = main
+	}
+	switch {
+	case pkg == "" || pkg == "main":
+		return base
+	case module != "" && pkg == module:
+		return base
+	case module != "" && strings.HasPrefix(pkg, module+"/"):
+		return strings.TrimPrefix(pkg, module+"/") + "/" + base
+	}
+	return pkg + "/" + base
+}
+
+// path renders the path of the file of the frame for the messages, the
+// stack and the codecs.
+func (c *Capturer) path(f Frame) string {
+	switch c.cfg.Path {
+	case PathModule:
+		return modulePath(f)
+	case PathTrim:
+		for _, prefix := range c.cfg.TrimPrefix {
+			if strings.HasPrefix(f.File, prefix) {
+				return strings.TrimPrefix(f.File, prefix)
+			}
+		}
+		return f.File
+	case PathFull:
+		return f.File
+	case PathBase:
+		return path.Base(f.File)
+	}
+	return shortFile(f.File)
+}
+
+// file returns the path of the file where the error occurred as it is
+// rendered in the messages. The paths of the errors created in this process
+// are already rendered by caller, the errors decoded from other processes
+// are rendered with the Config of the package.
+func (e *Error) file() string {
+	if e.capturer != nil {
+		return e.caller().File
+	}
+	return current().path(e.caller())
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPathModes(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	dir := filepath.Dir(file) + "/"
+	for _, test := range []struct {
+		mode         PathMode
+		display, raw string
+	}{
+		{PathShort, shortFile(file), shortFile(file)},
+		{PathModule, "path_test.go", "path_test.go"},
+		{PathTrim, "path_test.go", "path_test.go"},
+		{PathFull, file, file},
+		{PathBase, "path_test.go", "path_test.go"},
+	} {
+		c := With(Config{Callers: true, Path: test.mode, TrimPrefix: []string{dir}})
+		err := c.New(ErrDummy).(*Error)
+		if err.File() != test.display {
+			t.Fatal(test.mode, "wrong file", err.File())
+		}
+		if !strings.Contains(err.Error(), " - "+test.display+" - ") {
+			t.Fatal(test.mode, "wrong error", err.Error())
+		}
+		if !strings.Contains(err.GoString(), " - file: "+test.display+" - ") {
+			t.Fatal(test.mode, "wrong go string", err.GoString())
+		}
+		if f := err.StackTrace()[0].File; f != test.raw {
+			t.Fatal(test.mode, "wrong stack", f)
+		}
+		if !strings.Contains(err.trace("", "", (*Error).formatStack), "\t\t"+test.raw+":") {
+			t.Fatal(test.mode, "wrong trace", err.trace("", "", (*Error).formatStack))
+		}
+		if f := gobRoundTrip(t, err).StackTrace()[0].File; f != test.raw {
+			t.Fatal(test.mode, "wrong stack after gob", f)
+		}
+		b, er := json.Marshal(err)
+		if er != nil {
+			t.Fatal(er)
+		}
+		var je struct {
+			File string `json:"file"`
+		}
+		if er := json.Unmarshal(b, &je); er != nil {
+			t.Fatal(er)
+		}
+		if je.File != test.display {
+			t.Fatal(test.mode, "wrong json file", je.File)
+		}
+	}
+}
+
+func TestPathTrimOnce(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	dir := filepath.Dir(file)
+	c := With(Config{Callers: true, Path: PathTrim, TrimPrefix: []string{filepath.Dir(dir) + "/", filepath.Base(dir) + "/"}})
+	err := c.New(ErrDummy).(*Error)
+	want := filepath.Base(dir) + "/path_test.go"
+	if err.File() != want || err.StackTrace()[0].File != want {
+		t.Fatal("wrong file", err.File(), err.StackTrace()[0].File)
+	}
+	if !strings.Contains(err.Error(), " - "+want+" - ") {
+		t.Fatal("wrong error", err.Error())
+	}
+}
+
+func TestPathDefaultCodecs(t *testing.T) {
+	withConfig(t, DefaultConfig)
+	_, file, _, _ := runtime.Caller(0)
+	dir := filepath.Dir(file)
+	err := New("value %v", 1).(*Error).Push(Merge(ErrSilly, New(ErrDummy)))
+	b, er := encodeGob(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	m, er := encodeMsgpack(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	j, er := json.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	for name, data := range map[string][]byte{"gob": b, "msgpack": m, "json": j} {
+		if bytes.Contains(data, []byte(dir)) || bytes.Contains(data, []byte(runtime.GOROOT())) {
+			t.Fatal(name, "sent an absolute path")
+		}
+	}
+	if s := fmt.Sprintf("%+v", err); strings.Contains(s, dir) {
+		t.Fatal("absolute path in the stack", s)
+	}
+	if !strings.Contains(TraceWithSource(err, 0), "> ") {
+		t.Fatal("source not found", TraceWithSource(err, 0))
+	}
+}
+
+func TestModulePath(t *testing.T) {
+	for _, test := range []struct {
+		function, file, path string
+	}{
+		{"github.com/fcavani/e.New", "/src/e/error.go", "error.go"},
+		{"github.com/fcavani/e/safe.New[...]", "/src/e/safe/safe.go", "safe/safe.go"},
+		{"github.com/fcavani/e.FindType[go.shape.*github.com/x/y.T]", "/src/e/find.go", "find.go"},
+		{"github.com/fcavani/types.Name", "/go/pkg/mod/github.com/fcavani/types@v1/types.go", "github.com/fcavani/types/types.go"},
+		{"runtime.goexit", "/usr/go/src/runtime/asm_amd64.s", "runtime/asm_amd64.s"},
+		{"", "/src/x.go", "x.go"},
+	} {
+		if p := modulePath(Frame{Function: test.function, File: test.file}); p != test.path {
+			t.Fatal("wrong path", test.function, p)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ProblemContentType is the media type of the problem details of RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// InstanceField is the key of the field that holds the instance of the
+// problem. Without it WriteProblem generates one.
+const InstanceField = "instance"
+
+// ProblemConfig sets how the problems are made.
+type ProblemConfig struct {
+	// TypeBase prefixes the kind in the type URI of the problems.
+	TypeBase string
+	// DevMode adds the debug information, the pkg, file and line where the
+	// error occurred, to the problems. Don't set it in production, it shows
+	// the internals of the server to the clients.
+	DevMode bool
+}
+
+var defaultProblemConfig = ProblemConfig{
+	TypeBase: "urn:fcavani:e:kind:",
+}
+
+var problemConfig atomic.Pointer[ProblemConfig]
+
+func init() {
+	SetProblemConfig(defaultProblemConfig)
+}
+
+// SetProblemConfig replaces the ProblemConfig used by NewProblem,
+// WriteProblem and ProblemType.
+func SetProblemConfig(cfg ProblemConfig) {
+	problemConfig.Store(&cfg)
+}
+
+// CurrentProblemConfig returns the ProblemConfig used by NewProblem,
+// WriteProblem and ProblemType. By default the type URIs start with
+// urn:fcavani:e:kind: and the debug information isn't added.
+func CurrentProblemConfig() ProblemConfig {
+	return *problemConfig.Load()
+}
+
+var statuses = struct {
+	sync.RWMutex
+	kinds map[Kind]int
+}{
+	kinds: map[Kind]int{
+		ErrEmptyString:      http.StatusBadRequest,
+		ErrInvalidType:      http.StatusBadRequest,
+		ErrInvalidLength:    http.StatusBadRequest,
+		ErrInvalidInterface: http.StatusBadRequest,
+	},
+}
+
+// SetStatus maps kind to an HTTP status. The kinds of this package are
+// mapped to 400 Bad Request.
+func SetStatus(kind Kind, status int) {
+	statuses.Lock()
+	defer statuses.Unlock()
+	statuses.kinds[kind] = status
+}
+
+// StatusOf returns the HTTP status of err: the status of its kind, see
+// KindOf and SetStatus, or 503 Service Unavailable if err is retryable,
+// or 500 Internal Server Error.
+func StatusOf(err error) int {
+	statuses.RLock()
+	status, found := statuses.kinds[KindOf(err)]
+	statuses.RUnlock()
+	if found {
+		return status
+	}
+	if IsRetryable(err) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// ProblemType returns the type URI of the problems of kind, about:blank
+// if kind is empty. The URI is the kind in lower case, with dashes
+// instead of spaces, after the TypeBase of the ProblemConfig.
+func ProblemType(kind Kind) string {
+	if kind == "" {
+		return "about:blank"
+	}
+	slug := strings.Join(strings.Fields(strings.ToLower(string(kind))), "-")
+	return CurrentProblemConfig().TypeBase + url.PathEscape(slug)
+}
+
+// Problem is the problem details of RFC 7807 with the kind and the class
+// of the error as extensions.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Kind     Kind   `json:"kind,omitempty"`
+	Class    string `json:"class,omitempty"`
+	// Debug information, only in DevMode.
+	Pkg  string `json:"pkg,omitempty"`
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// NewProblem returns the problem of err. The title is the kind or the
+// text of the status, the detail is the message of the *Error in err, see
+// Human, or the text of the status if there isn't one or if err is a
+// panic, and the instance is the field InstanceField of err or a new URN.
+func NewProblem(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+	status := StatusOf(err)
+	kind := KindOf(err)
+	p := &Problem{
+		Type:   ProblemType(kind),
+		Title:  string(kind),
+		Status: status,
+		Detail: http.StatusText(status),
+		Kind:   kind,
+		Class:  ClassOf(err).String(),
+	}
+	if kind == "" {
+		p.Title = http.StatusText(status)
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		if kind != ErrPanic {
+			p.Detail = e.Human()
+		}
+		if instance, ok := e.Fields()[InstanceField]; ok {
+			p.Instance = fmt.Sprint(instance)
+		}
+		if CurrentProblemConfig().DevMode && e.debugInfo {
+			caller := e.caller()
+			p.Pkg = caller.Function
+			p.File = e.file()
+			p.Line = caller.Line
+		}
+	}
+	if p.Instance == "" {
+		p.Instance = newInstance()
+	}
+	return p
+}
+
+// newInstance returns a random UUID URN.
+func newInstance() string {
+	var u [16]byte
+	_, _ = rand.Read(u[:])
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+}
+
+// WriteProblem writes the problem of err, see NewProblem, to w with the
+// status of err. err must not be nil.
+func WriteProblem(w http.ResponseWriter, err error) error {
+	p := NewProblem(err)
+	if p == nil {
+		return New(ErrInvalidInterface)
+	}
+	return p.write(w)
+}
+
+func (p *Problem) write(w http.ResponseWriter) error {
+	b, er := json.Marshal(p)
+	if er != nil {
+		return er
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_, er = w.Write(b)
+	return er
+}
+
+// FromProblem reads the problem in the body of resp and returns it as an
+// *Error. The message is the detail, or the title if there isn't a
+// detail, and the error has the kind and the class of the problem. The
+// status, the type and the instance are fields of the error. The body is
+// bounded by the Size of DecodeLimits.
+func FromProblem(resp *http.Response) (*Error, error) {
+	mt, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mt != ProblemContentType {
+		return nil, fmt.Errorf("content type isn't %v: %q", ProblemContentType, resp.Header.Get("Content-Type"))
+	}
+	body := io.Reader(resp.Body)
+	limit := DecodeLimits().Size
+	if limit > 0 {
+		body = io.LimitReader(resp.Body, int64(limit)+1)
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(b) > limit {
+		return nil, &DecodeError{Limit: "size", Max: limit}
+	}
+	var p Problem
+	err = json.Unmarshal(b, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p.Err()
+}
+
+// Err returns the problem as an *Error, see FromProblem.
+func (p *Problem) Err() (*Error, error) {
+	class, err := parseClass(p.Class)
+	if err != nil {
+		return nil, err
+	}
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+	e := &Error{
+		err:   GoError(msg),
+		kind:  p.Kind,
+		class: class,
+	}
+	if p.Kind != "" && msg == string(p.Kind) {
+		e.err = p.Kind
+	}
+	if p.Pkg != "" || p.File != "" {
+		e.debugInfo = true
+		e.frames = []Frame{{Function: p.Pkg, File: p.File, Line: p.Line}}
+	}
+	e.fields = []Field{{Key: "status", Value: p.Status}, {Key: "type", Value: p.Type}}
+	if p.Instance != "" {
+		e.fields = append(e.fields, Field{Key: InstanceField, Value: p.Instance})
+	}
+	return e, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+const ErrNotFound Kind = "not found"
+
+func init() {
+	SetStatus(ErrNotFound, http.StatusNotFound)
+}
+
+func TestStatusOf(t *testing.T) {
+	for _, test := range []struct {
+		err    error
+		status int
+	}{
+		{New(ErrNotFound), http.StatusNotFound},
+		{New("user %v", "foo", ErrNotFound), http.StatusNotFound},
+		{New(ErrInvalidType), http.StatusBadRequest},
+		{New(ErrDummy, Temporary), http.StatusServiceUnavailable},
+		{syscall.ECONNRESET, http.StatusServiceUnavailable},
+		{New(ErrDummy), http.StatusInternalServerError},
+		{io.EOF, http.StatusInternalServerError},
+	} {
+		if status := StatusOf(test.err); status != test.status {
+			t.Fatal("wrong status", test.err, status)
+		}
+	}
+	if ProblemType(ErrNotFound) != "urn:fcavani:e:kind:not-found" || ProblemType("") != "about:blank" {
+		t.Fatal("wrong type", ProblemType(ErrNotFound))
+	}
+	withProblemConfig(t, ProblemConfig{TypeBase: "https://example.com/problems/"})
+	if ProblemType(ErrNotFound) != "https://example.com/problems/not-found" {
+		t.Fatal("wrong type", ProblemType(ErrNotFound))
+	}
+}
+
+func TestProblemConfigRace(t *testing.T) {
+	withProblemConfig(t, CurrentProblemConfig())
+	err := New(ErrNotFound)
+	concurrently(t, func(i int) {
+		if i%2 == 0 {
+			SetProblemConfig(ProblemConfig{TypeBase: "urn:test:", DevMode: i%4 == 0})
+			return
+		}
+		_ = NewProblem(err)
+	})
+}
+
+func TestWriteProblem(t *testing.T) {
+	err := New("user %v", "foo", ErrNotFound, Field{Key: InstanceField, Value: "/users/foo"})
+	w := httptest.NewRecorder()
+	if er := WriteProblem(w, err); er != nil {
+		t.Fatal(er)
+	}
+	if w.Code != http.StatusNotFound || w.Header().Get("Content-Type") != ProblemContentType {
+		t.Fatal("wrong response", w.Code, w.Header())
+	}
+	var p map[string]interface{}
+	if er := json.Unmarshal(w.Body.Bytes(), &p); er != nil {
+		t.Fatal(er)
+	}
+	want := map[string]interface{}{
+		"type":     "urn:fcavani:e:kind:not-found",
+		"title":    "not found",
+		"status":   float64(404),
+		"detail":   "user foo",
+		"instance": "/users/foo",
+		"kind":     "not found",
+	}
+	if len(p) != len(want) {
+		t.Fatal("wrong problem", p)
+	}
+	for k, v := range want {
+		if p[k] != v {
+			t.Fatal("wrong problem", k, p[k])
+		}
+	}
+	w = httptest.NewRecorder()
+	if er := WriteProblem(w, New(ErrDummy)); er != nil {
+		t.Fatal(er)
+	}
+	pr := NewProblem(New(ErrDummy))
+	if pr.Title != "Internal Server Error" || pr.Type != "about:blank" || !strings.HasPrefix(pr.Instance, "urn:uuid:") {
+		t.Fatal("wrong problem", pr)
+	}
+	if pr.Pkg != "" || pr.File != "" || pr.Line != 0 {
+		t.Fatal("debug information without DevMode")
+	}
+}
+
+// withProblemConfig sets cfg until the end of the test.
+func withProblemConfig(t *testing.T, cfg ProblemConfig) {
+	old := CurrentProblemConfig()
+	SetProblemConfig(cfg)
+	t.Cleanup(func() { SetProblemConfig(old) })
+}
+
+func TestWriteProblemDevMode(t *testing.T) {
+	withProblemConfig(t, ProblemConfig{TypeBase: CurrentProblemConfig().TypeBase, DevMode: true})
+	err := New(ErrDummy).(*Error)
+	p := NewProblem(err)
+	if p.Pkg != err.Pkg() || p.File != err.File() || p.Line != err.Line() || p.Line == 0 {
+		t.Fatal("wrong debug information", p)
+	}
+}
+
+func TestFromProblem(t *testing.T) {
+	withProblemConfig(t, ProblemConfig{TypeBase: CurrentProblemConfig().TypeBase, DevMode: true})
+	orig := New("user %v", "foo", ErrNotFound, Permanent).(*Error)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = WriteProblem(w, orig)
+	}))
+	defer srv.Close()
+	resp, er := http.Get(srv.URL)
+	if er != nil {
+		t.Fatal(er)
+	}
+	defer resp.Body.Close()
+	err, er := FromProblem(resp)
+	if er != nil {
+		t.Fatal(er)
+	}
+	if err.Human() != "user foo" || KindOf(err) != ErrNotFound || !IsPermanent(err) {
+		t.Fatal("wrong error", err.Human(), KindOf(err))
+	}
+	if err.Line() != orig.Line() || err.File() != orig.File() {
+		t.Fatal("wrong debug information", err.Line())
+	}
+	fields := err.Fields()
+	if fields["status"] != http.StatusNotFound || fields["type"] != ProblemType(ErrNotFound) || fields[InstanceField] == "" {
+		t.Fatal("wrong fields", fields)
+	}
+	if StatusOf(err) != http.StatusNotFound {
+		t.Fatal("wrong status")
+	}
+	p := &Problem{Title: "not found", Kind: ErrNotFound, Status: 404}
+	kerr, er := p.Err()
+	if er != nil || !errors.Is(kerr, ErrNotFound) {
+		t.Fatal("Is failed", er)
+	}
+	resp = &http.Response{Header: http.Header{"Content-Type": {"text/plain"}}, Body: io.NopCloser(strings.NewReader("{}"))}
+	if _, er := FromProblem(resp); er == nil {
+		t.Fatal("wrong content type accepted")
+	}
+	withLimits(t, Limits{Size: 10})
+	resp = &http.Response{Header: http.Header{"Content-Type": {ProblemContentType}}, Body: io.NopCloser(strings.NewReader(`{"title": "too long"}`))}
+	if _, er := FromProblem(resp); !errors.As(er, new(*DecodeError)) {
+		t.Fatal("size not limited", er)
+	}
+}
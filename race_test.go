@@ -0,0 +1,124 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// The tests in this file share errors between goroutines, run them with
+// go test -race.
+
+const goroutines = 16
+
+func sharedChain() *Error {
+	return New(ErrDummy).(*Error).Push(ErrStr).Push(ErrSilly)
+}
+
+func concurrently(t *testing.T, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestRacePushSentinel(t *testing.T) {
+	sentinel := New(ErrAnother).(*Error)
+	base := sharedChain()
+	trace := base.Trace()
+	concurrently(t, func(i int) {
+		err := base.Push(sentinel)
+		if err.next != base {
+			t.Error("base isn't shared")
+		}
+		if Find(err, ErrDummy) != 3 {
+			t.Error("Find failed")
+		}
+		err = Push(sentinel, ErrStill).(*Error)
+		if err.next != sentinel {
+			t.Error("sentinel isn't shared")
+		}
+	})
+	if sentinel.next != nil {
+		t.Fatal("sentinel was modified")
+	}
+	if base.Trace() != trace {
+		t.Fatal("base was modified")
+	}
+}
+
+func TestRacePushChain(t *testing.T) {
+	base := sharedChain()
+	top := New(ErrAnother).(*Error).Push(ErrStill)
+	baseTrace := base.Trace()
+	topTrace := top.Trace()
+	concurrently(t, func(i int) {
+		err := base.Push(top)
+		if err == top || err.next == top.next {
+			t.Error("top wasn't copied")
+		}
+		if err.next.next != base {
+			t.Error("base isn't shared")
+		}
+		if err.Trace() != topTrace+baseTrace {
+			t.Error("wrong chain")
+		}
+	})
+	if base.Trace() != baseTrace {
+		t.Fatal("base was modified")
+	}
+	if top.Trace() != topTrace {
+		t.Fatal("top was modified")
+	}
+}
+
+func TestRaceForward(t *testing.T) {
+	base := sharedChain()
+	trace := base.Trace()
+	concurrently(t, func(i int) {
+		err := Forward(base).(*Error)
+		if err.next != base {
+			t.Error("base isn't shared")
+		}
+		if !errors.Is(err, ErrDummy) {
+			t.Error("Is failed")
+		}
+	})
+	if base.Trace() != trace {
+		t.Fatal("base was modified")
+	}
+}
+
+func TestRaceMerge(t *testing.T) {
+	e1 := sharedChain()
+	e2 := New(ErrAnother).(*Error)
+	trace1 := e1.Trace()
+	trace2 := e2.Trace()
+	concurrently(t, func(i int) {
+		err := Merge(e1, e2).(*Error)
+		c := err.Causes()
+		if c[0] != e1 || c[1] != e2 {
+			t.Error("causes aren't shared")
+		}
+		err = Merge(err, ErrStill).(*Error)
+		if Find(err, ErrDummy) != 4 {
+			t.Error("Find failed", Find(err, ErrDummy))
+		}
+	})
+	if e1.Trace() != trace1 {
+		t.Fatal("e1 was modified")
+	}
+	if e2.Trace() != trace2 || e2.next != nil {
+		t.Fatal("e2 was modified")
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fcavani/e/internal/format"
+	"github.com/fcavani/types"
+)
+
+var registry = struct {
+	sync.RWMutex
+	names map[reflect.Type]string
+	types map[string]reflect.Type
+}{
+	names: make(map[reflect.Type]string),
+	types: make(map[string]reflect.Type),
+}
+
+func init() {
+	for _, v := range []interface{}{
+		false, "",
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+		float32(0), float64(0),
+		[]byte(nil), formatted(""),
+		time.Time{}, time.Duration(0),
+		GoError(""),
+		Kind(""),
+		syscall.Errno(0),
+		&fs.PathError{},
+		&os.LinkError{},
+		&os.SyscallError{},
+	} {
+		Register(v)
+	}
+}
+
+// Register registers the concrete type of v, an error wrapped by an *Error
+// or an argument of the error message. The gob and msgpack codecs send the
+// values of the registered types with its type name and rebuild a value of
+// the same type when decoding. Other errors are sent as GoError. Other
+// arguments are sent as the basic type of their kind if their verbs render
+// them the same, or else already formatted by their verbs; if an argument
+// can't be rendered the same, like the argument of %T, the formatted
+// message is sent instead of the template. The type must be encodable by
+// gob, Register also registers it in gob and in the types package. The
+// basic types, byte slices, GoError, Kind, syscall.Errno and the errors of
+// the os package are already registered.
+func Register(v interface{}) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		panic("invalid type")
+	}
+	name := types.Name(v)
+	types.Insert(v)
+	gob.Register(v)
+	registry.Lock()
+	defer registry.Unlock()
+	registry.names[t] = name
+	registry.types[name] = t
+}
+
+func registeredName(v interface{}) (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	name, found := registry.names[reflect.TypeOf(v)]
+	return name, found
+}
+
+func registeredType(name string) (reflect.Type, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	t, found := registry.types[name]
+	return t, found
+}
+
+// value is a value of a registered type ready to be sent by the codecs.
+// Type is empty for nil values.
+type value struct {
+	Type string
+	Data []byte
+}
+
+func encodeValue(v interface{}) (value, error) {
+	if v == nil {
+		return value{}, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return value{}, nil
+	}
+	name, found := registeredName(v)
+	if !found {
+		return value{}, errors.New("type not registered: " + types.Name(v))
+	}
+	buf := bytes.NewBuffer([]byte{})
+	err := gob.NewEncoder(buf).Encode(v)
+	if err != nil {
+		return value{}, err
+	}
+	return value{Type: name, Data: buf.Bytes()}, nil
+}
+
+func (v value) decode() (interface{}, error) {
+	if v.Type == "" {
+		return nil, nil
+	}
+	t, found := registeredType(v.Type)
+	if !found {
+		return nil, errors.New("type not registered: " + v.Type)
+	}
+	dec := gob.NewDecoder(bytes.NewReader(v.Data))
+	if t.Kind() == reflect.Ptr {
+		p := reflect.New(t.Elem())
+		err := dec.DecodeValue(p)
+		if err != nil {
+			return nil, err
+		}
+		return p.Interface(), nil
+	}
+	p := reflect.New(t)
+	err := dec.DecodeValue(p)
+	if err != nil {
+		return nil, err
+	}
+	return p.Elem().Interface(), nil
+}
+
+// formatted is an argument sent already formatted by its directive, it is
+// rendered the same by any verb.
+type formatted string
+
+func (f formatted) Format(s fmt.State, verb rune) {
+	io.WriteString(s, string(f))
+}
+
+// basicTypes are the types of the basic kinds that the codecs send.
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+	reflect.String:  reflect.TypeOf(""),
+}
+
+// sendable returns arg as the codecs send it when it is formatted by
+// directive, known tells if the codec sends the type of the value as is.
+// An error is sent as GoError and a value of a named type of a basic kind,
+// or a byte slice, as the basic type if the verb renders them the same. It
+// returns false if the value can only be sent formatted.
+func sendable(arg interface{}, directive string, known func(interface{}) bool) (interface{}, bool) {
+	if arg == nil || known(arg) {
+		return arg, true
+	}
+	if rv := reflect.ValueOf(arg); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, true
+	}
+	verb := directive[len(directive)-1]
+	if verb == 'T' || verb == 'p' || strings.Contains(directive, "#") {
+		// The type or the address is rendered.
+		return nil, false
+	}
+	if _, ok := arg.(fmt.Formatter); ok {
+		return nil, false
+	}
+	_, stringer := arg.(fmt.Stringer)
+	err, isError := arg.(error)
+	if strings.IndexByte("vsqxX", verb) >= 0 {
+		if isError {
+			if verb == 'v' || verb == 's' {
+				return GoError(err.Error()), true
+			}
+			return nil, false
+		}
+		if stringer {
+			return nil, false
+		}
+	}
+	rv := reflect.ValueOf(arg)
+	if t, found := basicTypes[rv.Kind()]; found {
+		return rv.Convert(t).Interface(), true
+	}
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+		return rv.Convert(reflect.TypeOf([]byte(nil))).Interface(), true
+	}
+	return nil, false
+}
+
+// encodeArgs prepares the arguments of template to be sent. The arguments
+// of types that aren't registered are sent as the basic type of their kind
+// if their verbs render them the same, errors as GoError, or already
+// formatted by their directives. It returns false if an argument can't be
+// sent in a way that it is rendered the same, like the arguments of %T.
+func encodeArgs(template string, args []interface{}) ([]value, bool, error) {
+	if len(args) == 0 {
+		return nil, true, nil
+	}
+	directives := format.Directives(template, len(args))
+	vals := make([]value, 0, len(args))
+	for i, arg := range args {
+		d := directives[i]
+		if d == "" {
+			d = "%v"
+		}
+		v, ok := sendable(arg, d, isRegistered)
+		if !ok && !formattable(d) {
+			return nil, false, nil
+		}
+		if !ok {
+			v = formatted(fmt.Sprintf(d, arg))
+		}
+		val, err := encodeValue(v)
+		if err != nil {
+			return nil, false, err
+		}
+		vals = append(vals, val)
+	}
+	return vals, true, nil
+}
+
+// formattable returns false for the directives that don't render a
+// formatted argument the same: %T and %p.
+func formattable(directive string) bool {
+	verb := directive[len(directive)-1]
+	return verb != 'T' && verb != 'p'
+}
+
+func isRegistered(v interface{}) bool {
+	_, found := registeredName(v)
+	return found
+}
+
+// field is a Field ready to be sent.
+type field struct {
+	Key   string
+	Value value
+}
+
+// encodeFields prepares the fields to be sent, the values are replaced like
+// the arguments formatted by %v but the values of types that can't be sent
+// are sent as strings.
+func encodeFields(fields []Field) ([]field, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	fs := make([]field, 0, len(fields))
+	for _, f := range fields {
+		v, ok := sendable(f.Value, "%v", isRegistered)
+		if !ok {
+			v = fmt.Sprint(f.Value)
+		}
+		val, err := encodeValue(v)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, field{Key: f.Key, Value: val})
+	}
+	return fs, nil
+}
+
+// isRegisteredError returns true if the error must be sent as a registered
+// value.
+func isRegisteredError(err error) bool {
+	if _, ok := err.(GoError); ok {
+		return false
+	}
+	_, found := registeredName(err)
+	return found
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"os"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+
+	"gopkg.in/vmihailenco/msgpack.v2"
+)
+
+type customArg struct {
+	Name  string
+	Count int
+}
+
+type customError struct {
+	Code int
+	Msg  string
+}
+
+func (c *customError) Error() string {
+	return c.Msg
+}
+
+type unregisteredError struct{}
+
+func (unregisteredError) Error() string {
+	return "unregistered"
+}
+
+func init() {
+	Register(customArg{})
+	Register(&customError{})
+}
+
+func gobRoundTrip(t *testing.T, err *Error) *Error {
+	buf := bytes.NewBuffer([]byte{})
+	if er := gob.NewEncoder(buf).Encode(err); er != nil {
+		t.Fatal(er)
+	}
+	var gerr *Error
+	if er := gob.NewDecoder(buf).Decode(&gerr); er != nil {
+		t.Fatal(er)
+	}
+	return gerr
+}
+
+func msgpackRoundTrip(t *testing.T, err *Error) *Error {
+	b, er := msgpack.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var merr *Error
+	if er := msgpack.Unmarshal(b, &merr); er != nil {
+		t.Fatal(er)
+	}
+	return merr
+}
+
+func TestRegisteredTypes(t *testing.T) {
+	_, perr := os.Open("/this/file/does/not/exist")
+	if perr == nil {
+		t.Fatal("file exists")
+	}
+	now := time.Now().UTC()
+	args := []interface{}{42, int8(-1), uint16(7), 0.5, "foo", true, nil, now, time.Second, customArg{Name: "bar", Count: 3}, unregisteredError{}, []int{1}}
+	err := New("%v %v %v %v %v %v %v %v %v %v %v %v", args...).(*Error).Push(&customError{Code: 7, Msg: "custom"}).Push(perr)
+	for name, rt := range map[string]func(*testing.T, *Error) *Error{"gob": gobRoundTrip, "msgpack": msgpackRoundTrip} {
+		derr := rt(t, err)
+		var pathErr *os.PathError
+		if !errors.As(derr, &pathErr) {
+			t.Fatal(name, "As failed")
+		}
+		if pathErr.Path != "/this/file/does/not/exist" || pathErr.Err != syscall.ENOENT {
+			t.Fatalf("%v: wrong error: %#v", name, pathErr)
+		}
+		if !errors.Is(derr, os.ErrNotExist) {
+			t.Fatal(name, "Is failed")
+		}
+		var cerr *customError
+		if !errors.As(derr, &cerr) || cerr.Code != 7 {
+			t.Fatal(name, "As failed (2)")
+		}
+		got := derr.next.next.Arguments()
+		want := append([]interface{}{}, args[:10]...)
+		want = append(want, GoError("unregistered"), formatted("[1]"))
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%v: wrong args:\n%#v\n%#v", name, got, want)
+		}
+		if derr.Trace() != err.Trace() {
+			t.Fatalf("%v: wrong trace:\n%v", name, derr.Trace())
+		}
+	}
+}
+
+type wireID int
+
+type wireStringer uint8
+
+func (s wireStringer) String() string {
+	return "stringer"
+}
+
+func TestUnregisteredArgs(t *testing.T) {
+	withConfig(t, Config{})
+	for _, err := range []*Error{
+		New("took %d for %x id %d", time.Second, []byte{1, 2}, wireID(5)).(*Error),
+		New("%v, %T, %#v, %s, %d, %08.3f, %v", wireID(5), wireID(6), wireID(7), wireStringer(1), wireStringer(2), 1.5, &customArg{}).(*Error),
+		New("%[2]*[1]d|%-8v|%q", wireID(5), 4, wireStringer(3), unregisteredError{}).(*Error),
+	} {
+		for name, rt := range map[string]func(*testing.T, *Error) *Error{"gob": gobRoundTrip, "msgpack": msgpackRoundTrip} {
+			if derr := rt(t, err); derr.Trace() != err.Trace() {
+				t.Fatalf("%v: wrong trace:\n%v\n%v", name, derr.Trace(), err.Trace())
+			}
+		}
+	}
+	derr := gobRoundTrip(t, New("%x %d", []byte{1, 2}, wireID(5)).(*Error))
+	if !reflect.DeepEqual(derr.Arguments(), []interface{}{[]byte{1, 2}, 5}) {
+		t.Fatalf("wrong args: %#v", derr.Arguments())
+	}
+}
+
+func TestRegisterNil(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("no panic")
+		}
+	}()
+	Register(nil)
+}
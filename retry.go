@@ -0,0 +1,97 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy sets how Retry repeats an operation.
+type Policy struct {
+	// Attempts is the maximum number of calls, zero or less means no
+	// limit other than the context.
+	Attempts int
+	// Delay is the wait after the first failure.
+	Delay time.Duration
+	// MaxDelay bounds the wait, zero means no bound.
+	MaxDelay time.Duration
+	// Multiplier multiplies the wait after each failure, values less than
+	// one are taken as two.
+	Multiplier float64
+	// Jitter is the fraction, from zero to one, of each wait that is
+	// removed at random, so clients that failed together don't retry
+	// together.
+	Jitter float64
+}
+
+// DefaultPolicy is a policy with five attempts and waits from 100ms to 10s.
+var DefaultPolicy = Policy{
+	Attempts:   5,
+	Delay:      100 * time.Millisecond,
+	MaxDelay:   10 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+// wait returns the wait after the failure of attempt n, starting from zero.
+func (p Policy) wait(n int) time.Duration {
+	mult := p.Multiplier
+	if mult < 1 {
+		mult = 2
+	}
+	d := float64(p.Delay)
+	for i := 0; i < n; i++ {
+		d *= mult
+		if p.MaxDelay > 0 && d >= float64(p.MaxDelay) {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	if j := min(max(p.Jitter, 0), 1); j > 0 {
+		d -= d * j * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// Retry calls fn until it succeeds, the attempts of policy are exhausted,
+// ctx is done or fn returns a permanent error, see ClassOf. The errors that
+// aren't classified are retried too. If fn never succeeds Retry returns an
+// *Error with the failures of all attempts merged in it, in the order they
+// happened, followed by the error of ctx if it ended the retries. The
+// returned error has the class of the last failure.
+func Retry(ctx context.Context, policy Policy, fn func(ctx context.Context) error) error {
+	var failures causes
+	for n := 0; policy.Attempts <= 0 || n < policy.Attempts; n++ {
+		if err := ctx.Err(); err != nil {
+			failures = append(failures, newm(err))
+			break
+		}
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, newm(err))
+		if IsPermanent(err) || n+1 == policy.Attempts {
+			break
+		}
+		timer := time.NewTimer(policy.wait(n))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			failures = append(failures, newm(ctx.Err()))
+		case <-timer.C:
+			continue
+		}
+		break
+	}
+	merged := newError(failures, 2).(*Error)
+	merged.class = ClassOf(failures[len(failures)-1])
+	return merged
+}
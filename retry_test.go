@@ -0,0 +1,107 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var testPolicy = Policy{Attempts: 4, Delay: time.Millisecond, MaxDelay: 4 * time.Millisecond, Jitter: 0.5}
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), testPolicy, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return New(ErrDummy, Temporary)
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatal("retry failed", err, calls)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), testPolicy, func(ctx context.Context) error {
+		calls++
+		return New("attempt %v", calls)
+	})
+	if calls != 4 {
+		t.Fatal("wrong number of calls", calls)
+	}
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatal("not an *Error")
+	}
+	c := e.Causes()
+	if len(c) != 4 || c[0].Human() != "attempt 1" || c[3].Human() != "attempt 4" {
+		t.Fatal("wrong causes", err)
+	}
+	if e.Human() != "attempt 1; attempt 2; attempt 3; attempt 4" {
+		t.Fatal("wrong message", e.Human())
+	}
+}
+
+func TestRetryPermanent(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), testPolicy, func(ctx context.Context) error {
+		calls++
+		if calls == 2 {
+			return Push(syscall.ENOENT, ErrDummy, Permanent)
+		}
+		return syscall.ECONNRESET
+	})
+	if calls != 2 || !IsPermanent(err) {
+		t.Fatal("didn't stop", calls)
+	}
+	if !errors.Is(err, syscall.ECONNRESET) || !errors.Is(err, ErrDummy) {
+		t.Fatal("failures lost", err)
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := Retry(ctx, Policy{Delay: time.Hour}, func(ctx context.Context) error {
+		calls++
+		cancel()
+		return New(ErrDummy, Retryable)
+	})
+	if calls != 1 || !errors.Is(err, context.Canceled) || !errors.Is(err, ErrDummy) {
+		t.Fatal("context ignored", calls, err)
+	}
+	err = Retry(ctx, testPolicy, func(ctx context.Context) error {
+		t.Fatal("called with a done context")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatal("wrong error", err)
+	}
+}
+
+func TestPolicyWait(t *testing.T) {
+	p := Policy{Delay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	for n, want := range []time.Duration{10, 20, 40, 50, 50} {
+		if d := p.wait(n); d != want*time.Millisecond {
+			t.Fatal("wrong wait", n, d)
+		}
+	}
+	p.Jitter = 0.5
+	for n := 0; n < 100; n++ {
+		if d := p.wait(1); d < 10*time.Millisecond || d > 20*time.Millisecond {
+			t.Fatal("wrong jitter", d)
+		}
+	}
+	if d := (Policy{Delay: time.Second, Multiplier: 3}).wait(2); d != 9*time.Second {
+		t.Fatal("wrong multiplier", d)
+	}
+}
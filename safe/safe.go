@@ -0,0 +1,175 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+// Package safe is the API of package e checked at compile time. The
+// functions of package e take interface{} and panic on unsupported types,
+// the functions of safe take error or, for the messages, a type parameter
+// constrained by Text. Go doesn't allow unions with interfaces that have
+// methods, so a single string | error constraint can't be written.
+//
+// Where a runtime check is still needed, like a nil *e.Error in an error,
+// the functions return an *e.Error of kind e.ErrInvalidType instead of
+// panicking, with the value of the panic in the field "panic".
+package safe
+
+import (
+	"github.com/fcavani/e"
+)
+
+// Text is the constraint of the messages: strings and the string types,
+// like e.Kind.
+type Text interface {
+	~string
+}
+
+// guard replaces the panic of a call to package e by an error of kind
+// ErrInvalidType.
+func guard(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	*err = e.NewN(e.ErrInvalidType, 1, e.Field{Key: "panic", Value: r})
+}
+
+// New initiates an error from err, see e.New.
+func New(err error, a ...interface{}) (ret error) {
+	defer guard(&ret)
+	return e.NewN(err, 1, a...)
+}
+
+// NewN like New but with the stack deep to get the file name.
+func NewN(err error, n int, a ...interface{}) (ret error) {
+	defer guard(&ret)
+	return e.NewN(err, 1+n, a...)
+}
+
+// Newf initiates an error from the message format, the verbs in format are
+// replaced by a, see e.New.
+func Newf[T Text](format T, a ...interface{}) (ret error) {
+	defer guard(&ret)
+	return e.NewN(string(format), 1, a...)
+}
+
+// Push pushes e2 on the top of the chain of e1, see e.Push.
+func Push(e1, e2 error, opts ...e.Option) (ret error) {
+	defer guard(&ret)
+	return e.PushN(e1, e2, 1, opts...)
+}
+
+// PushN like Push but with the stack deep to get the file name.
+func PushN(e1, e2 error, n int, opts ...e.Option) (ret error) {
+	defer guard(&ret)
+	return e.PushN(e1, e2, 1+n, opts...)
+}
+
+// Pushf pushes an error with the message msg on the top of the chain of
+// e1, see e.Push.
+func Pushf[T Text](e1 error, msg T, opts ...e.Option) (ret error) {
+	defer guard(&ret)
+	return e.PushN(e1, string(msg), 1, opts...)
+}
+
+// PushNf like Pushf but with the stack deep to get the file name.
+func PushNf[T Text](e1 error, msg T, n int, opts ...e.Option) (ret error) {
+	defer guard(&ret)
+	return e.PushN(e1, string(msg), 1+n, opts...)
+}
+
+// Forward adds a new error to the chain of err with the same message, see
+// e.Forward.
+func Forward(err error) (ret error) {
+	defer guard(&ret)
+	return e.ForwardN(err, 1)
+}
+
+// Merge two errors, see e.Merge.
+func Merge(e1, e2 error) (ret error) {
+	defer guard(&ret)
+	return e.Merge(e1, e2)
+}
+
+// Copy creates a copy of err, see e.Copy.
+func Copy(err error) (ret error) {
+	defer guard(&ret)
+	return e.Copy(err)
+}
+
+// Equal compares if the errors are the same, see e.Equal. It is false if
+// the comparison fails.
+func Equal(l, r error) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return e.Equal(l, r)
+}
+
+// Find returns the deep of target in the chain of err, see e.Find, or -1
+// if it isn't found.
+func Find(err, target error) (deep int) {
+	defer func() {
+		if recover() != nil {
+			deep = -1
+		}
+	}()
+	return e.Find(err, target)
+}
+
+// FindStr returns the deep of the first error in the chain of err that
+// contains sub, see e.FindStr, or -1 if it isn't found. err doesn't need to
+// be an *e.Error.
+func FindStr[T Text](err error, sub T) (deep int) {
+	defer func() {
+		if recover() != nil {
+			deep = -1
+		}
+	}()
+	if _, ok := err.(*e.Error); !ok {
+		if e.Contains(err, string(sub)) {
+			return 0
+		}
+		return -1
+	}
+	return e.FindStr(err, string(sub))
+}
+
+// Contains checks if the message of err contains sub, see e.Contains.
+func Contains[T Text](err error, sub T) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return e.Contains(err, string(sub))
+}
+
+// Human returns the message of err, see e.Human.
+func Human(err error) (s string) {
+	defer func() {
+		if recover() != nil {
+			s = "nil"
+		}
+	}()
+	return e.Human(err)
+}
+
+// Phrase transforms the message in something readable, see e.Phrase. The
+// empty message stays empty.
+func Phrase[T Text](msg T) string {
+	if msg == "" {
+		return ""
+	}
+	return e.Phrase(string(msg))
+}
+
+// PhraseOf is like Phrase for the message of err.
+func PhraseOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	return Phrase(Human(err))
+}
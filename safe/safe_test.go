@@ -0,0 +1,88 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package safe
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fcavani/e"
+)
+
+const errTest e.Kind = "test"
+
+func TestNew(t *testing.T) {
+	err := New(errTest).(*e.Error)
+	if e.KindOf(err) != errTest || !strings.HasSuffix(err.File(), "safe_test.go") {
+		t.Fatal("wrong error", err.Trace())
+	}
+	err = Newf("value %v", 42).(*e.Error)
+	if err.Human() != "value 42" || !strings.HasSuffix(err.File(), "safe_test.go") {
+		t.Fatal("wrong error", err.Trace())
+	}
+	if Newf(errTest).(*e.Error).Human() != "test" || New(nil) != nil {
+		t.Fatal("wrong error")
+	}
+	if !strings.HasSuffix(NewN(io.EOF, 0).(*e.Error).File(), "safe_test.go") {
+		t.Fatal("wrong file")
+	}
+}
+
+func TestPush(t *testing.T) {
+	err := Push(io.EOF, errTest, e.Permanent).(*e.Error)
+	if err.Kind() != errTest || !e.IsPermanent(err) || !errors.Is(err, io.EOF) {
+		t.Fatal("wrong error", err.Trace())
+	}
+	if !strings.HasSuffix(err.File(), "safe_test.go") || !strings.HasSuffix(PushN(io.EOF, errTest, 0).(*e.Error).File(), "safe_test.go") {
+		t.Fatal("wrong file", err.File())
+	}
+	pushed := Pushf(io.EOF, "reading the header").(*e.Error)
+	if pushed.Human() != "reading the header" || !errors.Is(pushed, io.EOF) {
+		t.Fatal("wrong error", pushed.Trace())
+	}
+	if !strings.HasSuffix(pushed.File(), "safe_test.go") || !strings.HasSuffix(PushNf(io.EOF, "reading", 0).(*e.Error).File(), "safe_test.go") {
+		t.Fatal("wrong file", pushed.File())
+	}
+	if Find(err, io.EOF) != 1 || Find(err, errTest) != 0 || Find(err, io.ErrClosedPipe) != -1 {
+		t.Fatal("Find failed")
+	}
+	if FindStr(err, "EOF") != 1 || FindStr(io.EOF, "EOF") != 0 || FindStr(io.EOF, "foo") != -1 {
+		t.Fatal("FindStr failed")
+	}
+	if !Contains(err, "test") || Contains(err, "EOF") || !Equal(err, errTest) {
+		t.Fatal("Contains or Equal failed")
+	}
+	f := Forward(err).(*e.Error)
+	if f.Next() == nil || !strings.HasSuffix(f.File(), "safe_test.go") {
+		t.Fatal("Forward failed")
+	}
+	m := Merge(io.EOF, err)
+	if Human(m) != "EOF; test" || Human(Copy(err)) != "test" {
+		t.Fatal("Merge or Copy failed", Human(m))
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	var nilErr *e.Error
+	err := New(nilErr, errTest)
+	if e.KindOf(err) != e.ErrInvalidType || err.(*e.Error).Fields()["panic"] == nil {
+		t.Fatal("wrong error", err)
+	}
+	if Find(nilErr, io.EOF) != -1 || Equal(nilErr, io.EOF) || Human(nilErr) != "nil" {
+		t.Fatal("nil error accepted")
+	}
+}
+
+func TestPhrase(t *testing.T) {
+	if Phrase("some error") != "Some error." || Phrase(errTest) != "Test." || Phrase("") != "" {
+		t.Fatal("wrong phrase")
+	}
+	if PhraseOf(io.EOF) != "EOF." || PhraseOf(nil) != "" {
+		t.Fatal("wrong phrase")
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements slog.LogValuer. The error is logged as a group with
+// the message, the template, the arguments, the fields, the debug
+// information and the errors after it in the chain as causes.
+func (e *Error) LogValue() slog.Value {
+	if e == nil {
+		return slog.StringValue("nil")
+	}
+	attrs := e.logAttrs()
+	var causes []slog.Attr
+	for err := e.next; err != nil; err = err.next {
+		causes = append(causes, slog.Attr{
+			Key:   strconv.Itoa(len(causes)),
+			Value: slog.GroupValue(err.logAttrs()...),
+		})
+	}
+	if len(causes) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "causes", Value: slog.GroupValue(causes...)})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// logAttrs returns the attributes of one error of the chain. The errors
+// merged in it are logged as merged.
+func (e *Error) logAttrs() []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("message", e.Human()),
+		slog.String("template", e.err.Error()),
+	}
+	if len(e.args) > 0 {
+		attrs = append(attrs, slog.Any("args", e.args))
+	}
+	if e.kind != "" {
+		attrs = append(attrs, slog.String("kind", string(e.kind)))
+	}
+	if e.class != Unclassified {
+		attrs = append(attrs, slog.String("class", e.class.String()))
+	}
+	if len(e.fields) > 0 {
+		fields := make([]slog.Attr, 0, len(e.fields))
+		for _, f := range e.fields {
+			fields = append(fields, slog.Any(f.Key, f.Value))
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fields...)})
+	}
+	if e.debugInfo {
+		caller := e.caller()
+		symbol := caller.Symbol()
+		attrs = append(attrs,
+			slog.String("pkg", caller.Function),
+			slog.String("import", symbol.ImportPath),
+			slog.String("package", symbol.Package),
+			slog.String("function", symbol.Function),
+			slog.String("file", e.file()),
+			slog.Int("line", caller.Line),
+		)
+		if symbol.Receiver != "" {
+			attrs = append(attrs, slog.String("receiver", symbol.Receiver))
+		}
+		if len(symbol.Closure) > 0 {
+			attrs = append(attrs, slog.String("closure", symbol.closure()))
+		}
+	}
+	if !e.time.IsZero() {
+		attrs = append(attrs, slog.Time("time", e.time))
+	}
+	if e.goroutine != 0 {
+		attrs = append(attrs, slog.Uint64("goroutine", e.goroutine))
+	}
+	if c, ok := e.err.(causes); ok {
+		merged := make([]slog.Attr, 0, len(c))
+		for i, cause := range c {
+			merged = append(merged, slog.Attr{Key: strconv.Itoa(i), Value: cause.LogValue()})
+		}
+		attrs = append(attrs, slog.Attr{Key: "merged", Value: slog.GroupValue(merged...)})
+	}
+	return attrs
+}
+
+// LogHandler is a slog.Handler that expands the attributes holding an
+// *Error, or an error that wraps one, before passing the record to the
+// wrapped handler.
+type LogHandler struct {
+	h slog.Handler
+}
+
+// NewLogHandler wraps h.
+func NewLogHandler(h slog.Handler) *LogHandler {
+	return &LogHandler{h: h}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (l *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return l.h.Enabled(ctx, level)
+}
+
+// Handle expands the errors in r and calls the wrapped handler.
+func (l *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(expandAttr(a))
+		return true
+	})
+	return l.h.Handle(ctx, nr)
+}
+
+// WithAttrs returns a LogHandler whose wrapped handler has the expanded
+// attrs.
+func (l *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		expanded = append(expanded, expandAttr(a))
+	}
+	return &LogHandler{h: l.h.WithAttrs(expanded)}
+}
+
+// WithGroup returns a LogHandler whose wrapped handler has the group.
+func (l *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{h: l.h.WithGroup(name)}
+}
+
+func expandAttr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindGroup:
+		group := v.Group()
+		attrs := make([]slog.Attr, 0, len(group))
+		for _, ga := range group {
+			attrs = append(attrs, expandAttr(ga))
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	case slog.KindAny:
+		err, ok := v.Any().(error)
+		if !ok {
+			break
+		}
+		var e *Error
+		if !errors.As(err, &e) {
+			break
+		}
+		attrs := []slog.Attr{slog.String("error", err.Error())}
+		attrs = append(attrs, e.LogValue().Group()...)
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
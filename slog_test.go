@@ -0,0 +1,101 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+func logJSON(t *testing.T, h func(slog.Handler) slog.Handler, args ...interface{}) map[string]interface{} {
+	buf := bytes.NewBuffer([]byte{})
+	logger := slog.New(h(slog.NewJSONHandler(buf, nil)))
+	logger.Error("failed", args...)
+	var m map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatal(err, buf.String())
+	}
+	return m
+}
+
+func noHandler(h slog.Handler) slog.Handler {
+	return h
+}
+
+func logHandler(h slog.Handler) slog.Handler {
+	return NewLogHandler(h)
+}
+
+func TestLogValue(t *testing.T) {
+	err := New("bad value %v", 42).(*Error).Push(ErrStr)
+	m := logJSON(t, noHandler, "err", err)
+	attrs := m["err"].(map[string]interface{})
+	if attrs["message"] != ErrStr || attrs["template"] != ErrStr {
+		t.Fatal("wrong message", attrs)
+	}
+	if attrs["pkg"] != "github.com/fcavani/e.TestLogValue" || attrs["file"] != err.File() || attrs["line"] != float64(err.Line()) {
+		t.Fatal("wrong debug info", attrs)
+	}
+	causes := attrs["causes"].(map[string]interface{})
+	cause := causes["0"].(map[string]interface{})
+	if cause["message"] != "bad value 42" || cause["template"] != "bad value %v" {
+		t.Fatal("wrong cause", cause)
+	}
+	if fmt.Sprint(cause["args"]) != "[42]" {
+		t.Fatal("wrong args", cause["args"])
+	}
+	if _, found := cause["causes"]; found {
+		t.Fatal("cause has causes")
+	}
+}
+
+func TestLogValueMerge(t *testing.T) {
+	err := Merge(ErrDummy, ErrSilly)
+	m := logJSON(t, noHandler, "err", err)
+	merged := m["err"].(map[string]interface{})["merged"].(map[string]interface{})
+	if merged["0"].(map[string]interface{})["message"] != ErrDummy.Error() {
+		t.Fatal("wrong merged", merged)
+	}
+	if merged["1"].(map[string]interface{})["message"] != ErrSilly.Error() {
+		t.Fatal("wrong merged", merged)
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	err := New(ErrDummy).(*Error).Push(ErrStr)
+	wrapped := fmt.Errorf("wrapped: %w", err)
+	m := logJSON(t, noHandler, "err", wrapped)
+	if m["err"] != wrapped.Error() {
+		t.Fatal("wrong error", m["err"])
+	}
+	m = logJSON(t, logHandler, "err", wrapped, slog.Group("g", "inner", err), "n", 1)
+	attrs := m["err"].(map[string]interface{})
+	if attrs["error"] != wrapped.Error() || attrs["message"] != ErrStr {
+		t.Fatal("wrong error", attrs)
+	}
+	if _, found := attrs["causes"]; !found {
+		t.Fatal("causes not found", attrs)
+	}
+	inner := m["g"].(map[string]interface{})["inner"].(map[string]interface{})
+	if inner["message"] != ErrStr {
+		t.Fatal("wrong group", inner)
+	}
+	if m["n"] != float64(1) {
+		t.Fatal("wrong attr", m["n"])
+	}
+	m = logJSON(t, func(h slog.Handler) slog.Handler {
+		return NewLogHandler(h).WithAttrs([]slog.Attr{slog.Any("base", wrapped)}).WithGroup("grp")
+	}, "err", wrapped)
+	if m["base"].(map[string]interface{})["message"] != ErrStr {
+		t.Fatal("WithAttrs failed", m)
+	}
+	if m["grp"].(map[string]interface{})["err"].(map[string]interface{})["message"] != ErrStr {
+		t.Fatal("WithGroup failed", m)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// sources caches the lines of the source files read by TraceWithSource,
+// the files that can't be read are cached as nil.
+var sources = struct {
+	sync.Mutex
+	files map[string][]string
+}{
+	files: make(map[string][]string),
+}
+
+// sourceLines returns the lines of file or nil if it can't be read.
+func sourceLines(file string) []string {
+	sources.Lock()
+	defer sources.Unlock()
+	lines, found := sources.files[file]
+	if found {
+		return lines
+	}
+	b, err := os.ReadFile(file)
+	if err == nil {
+		lines = strings.Split(string(bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))), "\n")
+	}
+	sources.files[file] = lines
+	return lines
+}
+
+// TraceWithSource traces the error like Trace and renders after each error
+// with debug information the lines of the source around the line where it
+// occurred, contextLines before and after it, with the line marked with
+// '>'. The source files are read from the disk, and cached, so it is meant
+// for development: the errors whose files can't be read, like in a binary
+// running far from its source or decoded with paths not relative to the
+// working directory, see PathMode, are rendered without the source. If err
+// isn't an *Error, the first *Error wrapped by it is traced.
+func TraceWithSource(err error, contextLines int) string {
+	if err == nil {
+		return "nil"
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		return err.Error()
+	}
+	return e.trace("", "", func(err *Error, indent string) string {
+		return err.formatSource(indent, max(contextLines, 0))
+	})
+}
+
+// source returns the file and the line where the error occurred. The
+// file of the errors created in this process is the full path, whatever
+// is the PathMode, the errors decoded have the path sent.
+func (e *Error) source() (string, int) {
+	if len(e.frames) > 0 || len(e.stack) == 0 {
+		caller := e.caller()
+		return caller.File, caller.Line
+	}
+	f, _ := runtime.CallersFrames(e.stack).Next()
+	return f.File, f.Line
+}
+
+// formatSource renders the lines of the source around the line where the
+// error occurred.
+func (e *Error) formatSource(indent string, context int) (s string) {
+	if !e.debugInfo {
+		return
+	}
+	file, line := e.source()
+	lines := sourceLines(file)
+	if line <= 0 || line > len(lines) {
+		return
+	}
+	first := max(line-context, 1)
+	last := min(line+context, len(lines))
+	width := len(strconv.Itoa(last))
+	for n := first; n <= last; n++ {
+		mark := "  "
+		if n == line {
+			mark = "> "
+		}
+		num := strconv.Itoa(n)
+		s = s + indent + "\t" + mark + strings.Repeat(" ", width-len(num)) + num + " | " + lines[n-1] + "\n"
+	}
+	return
+}
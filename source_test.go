@@ -0,0 +1,55 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTraceWithSource(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	err := New(ErrDummy).(*Error) // source marker
+	trace := TraceWithSource(err, 1)
+	lines := strings.Split(trace, "\n")
+	if len(lines) != 5 || lines[0] != err.Error() {
+		t.Fatalf("wrong trace:\n%v", trace)
+	}
+	if lines[1] != "\t  "+strconv.Itoa(line)+" | \t_, file, line, _ := runtime.Caller(0)" ||
+		lines[2] != "\t> "+strconv.Itoa(line+1)+" | \terr := New(ErrDummy).(*Error) // source marker" ||
+		!strings.HasPrefix(lines[3], "\t  "+strconv.Itoa(line+2)+" | ") {
+		t.Fatalf("wrong source:\n%v", trace)
+	}
+	sources.Lock()
+	_, cached := sources.files[file]
+	sources.Unlock()
+	if !cached {
+		t.Fatal("source not cached")
+	}
+	merged := Merge(ErrSilly, err)
+	if !strings.Contains(TraceWithSource(merged, 0), "\n  - "+err.Error()+"\n    \t> "+strconv.Itoa(line+1)+" | ") {
+		t.Fatal("wrong merged source", TraceWithSource(merged, 0))
+	}
+	if TraceWithSource(fmt.Errorf("wrapped: %w", err), 1) != trace {
+		t.Fatal("wrong wrapped source", TraceWithSource(fmt.Errorf("wrapped: %w", err), 1))
+	}
+}
+
+func TestTraceWithSourceMissing(t *testing.T) {
+	err := &Error{err: ErrDummy, debugInfo: true, frames: []Frame{{Function: "main.main", File: "/nowhere/main.go", Line: 10}}}
+	if trace := TraceWithSource(err, 3); trace != err.Trace() {
+		t.Fatal("wrong trace", trace)
+	}
+	if trace := TraceWithSource(With(Config{}).New(ErrDummy), 3); trace != ErrDummy.Error()+"\n" {
+		t.Fatal("wrong trace", trace)
+	}
+	if TraceWithSource(nil, 1) != "nil" || TraceWithSource(ErrDummy, 1) != ErrDummy.Error() {
+		t.Fatal("wrong trace")
+	}
+}
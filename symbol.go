@@ -0,0 +1,200 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Symbol is the name of a function split in its parts. The name
+// github.com/user/repo/v2.(*Client).Do.func1.2 has the import path
+// github.com/user/repo/v2, the package repo, the receiver *Client, the
+// function Do and the closure 1.2, the second closure inside the first
+// closure of Do.
+type Symbol struct {
+	// ImportPath is the import path of the package of the function.
+	ImportPath string
+	// Package is the name of the package, taken from the import path
+	// without the major version, like repo for github.com/user/repo/v2 or
+	// yaml for gopkg.in/yaml.v3.
+	Package string
+	// Receiver is the type of the receiver of the method, like *Client,
+	// or empty for functions.
+	Receiver string
+	// Function is the name of the function or of the method. The package
+	// level closures are in the function glob.
+	Function string
+	// Closure are the indexes of the nested closures, starting from one,
+	// nil if the function isn't a closure.
+	Closure []int
+}
+
+// ParseSymbol splits name, a function name like the ones returned by
+// runtime.FuncForPC, in its parts. The type parameters of the generic
+// functions are removed.
+func ParseSymbol(name string) Symbol {
+	name = stripTypeParams(name)
+	var s Symbol
+	slash := strings.LastIndexByte(name, '/')
+	dot := strings.IndexByte(name[slash+1:], '.')
+	if dot < 0 {
+		s.Function = name
+		return s
+	}
+	s.ImportPath = strings.ReplaceAll(name[:slash+1+dot], "%2e", ".")
+	s.Package = packageName(s.ImportPath)
+	rest := name[slash+1+dot+1:]
+	if strings.HasPrefix(rest, "(") {
+		if i := strings.IndexByte(rest, ')'); i >= 0 {
+			s.Receiver = rest[1:i]
+			rest = strings.TrimPrefix(rest[i+1:], ".")
+		}
+	}
+	parts := strings.Split(rest, ".")
+	if s.Receiver == "" && len(parts) > 1 && parts[1] != "" && closureIndex(parts[1]) == 0 && !isNumber(parts[1]) {
+		s.Receiver = parts[0]
+		parts = parts[1:]
+	}
+	s.Function = parts[0]
+	for _, p := range parts[1:] {
+		if p == "" {
+			continue
+		}
+		if n := closureIndex(p); n > 0 {
+			s.Closure = append(s.Closure, n)
+		} else if n, err := strconv.Atoi(p); err == nil && s.Closure != nil {
+			s.Closure = append(s.Closure, n)
+		} else if err == nil {
+			// The init functions are named init.0, init.1...
+			s.Function += "." + p
+		}
+	}
+	return s
+}
+
+// String returns the name of the function with the receiver and the
+// closure, like github.com/user/repo/v2.(*Client).Do (closure 1.2).
+func (s Symbol) String() string {
+	var b strings.Builder
+	if s.ImportPath != "" {
+		b.WriteString(s.ImportPath)
+		b.WriteByte('.')
+	}
+	if s.Receiver != "" {
+		if strings.HasPrefix(s.Receiver, "*") {
+			b.WriteString("(" + s.Receiver + ").")
+		} else {
+			b.WriteString(s.Receiver + ".")
+		}
+	}
+	b.WriteString(s.Function)
+	if len(s.Closure) > 0 {
+		b.WriteString(" (closure " + s.closure() + ")")
+	}
+	return b.String()
+}
+
+// closure returns the indexes of the closures separated by dots.
+func (s Symbol) closure() string {
+	idx := make([]string, 0, len(s.Closure))
+	for _, n := range s.Closure {
+		idx = append(idx, strconv.Itoa(n))
+	}
+	return strings.Join(idx, ".")
+}
+
+// stripTypeParams removes the type parameters, the text between brackets.
+func stripTypeParams(name string) string {
+	if !strings.Contains(name, "[") {
+		return name
+	}
+	var b strings.Builder
+	depth := 0
+	for _, r := range name {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// packageName returns the last element of the import path without the
+// major version.
+func packageName(importPath string) string {
+	name := path.Base(importPath)
+	if len(name) > 1 && name[0] == 'v' && isNumber(name[1:]) && strings.Contains(importPath, "/") {
+		name = path.Base(path.Dir(importPath))
+	}
+	if i := strings.LastIndex(name, ".v"); i > 0 && isNumber(name[i+2:]) {
+		name = name[:i]
+	}
+	return name
+}
+
+// closureIndex returns the index of the closures named func1, gowrap1 and
+// deferwrap1 or zero.
+func closureIndex(s string) int {
+	for _, prefix := range []string{"func", "gowrap", "deferwrap"} {
+		if strings.HasPrefix(s, prefix) && isNumber(s[len(prefix):]) {
+			n, _ := strconv.Atoi(s[len(prefix):])
+			return n
+		}
+	}
+	return 0
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Symbol returns the name of the function of the frame split in its parts.
+func (f Frame) Symbol() Symbol {
+	return ParseSymbol(f.Function)
+}
+
+// ImportPath returns the import path of the package where the error
+// occurred.
+func (e *Error) ImportPath() string {
+	return e.caller().Symbol().ImportPath
+}
+
+// Package returns the name of the package where the error occurred.
+func (e *Error) Package() string {
+	return e.caller().Symbol().Package
+}
+
+// Receiver returns the type of the receiver of the method where the error
+// occurred, empty if it occurred in a function.
+func (e *Error) Receiver() string {
+	return e.caller().Symbol().Receiver
+}
+
+// Function returns the name of the function or of the method where the
+// error occurred, without the package and the receiver.
+func (e *Error) Function() string {
+	return e.caller().Symbol().Function
+}
+
+// Closure returns the indexes of the nested closures where the error
+// occurred, nil if it didn't occur in a closure. See Symbol.
+func (e *Error) Closure() []int {
+	return e.caller().Symbol().Closure
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSymbol(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		symbol Symbol
+		str    string
+	}{
+		{"github.com/fcavani/e.New", Symbol{"github.com/fcavani/e", "e", "", "New", nil}, "github.com/fcavani/e.New"},
+		{"github.com/fcavani/e.(*Error).Push", Symbol{"github.com/fcavani/e", "e", "*Error", "Push", nil}, "github.com/fcavani/e.(*Error).Push"},
+		{"github.com/fcavani/e.Frame.Symbol", Symbol{"github.com/fcavani/e", "e", "Frame", "Symbol", nil}, "github.com/fcavani/e.Frame.Symbol"},
+		{"github.com/fcavani/e.TestX.func1.2", Symbol{"github.com/fcavani/e", "e", "", "TestX", []int{1, 2}}, "github.com/fcavani/e.TestX (closure 1.2)"},
+		{"github.com/fcavani/e.(*T[...]).M.func3", Symbol{"github.com/fcavani/e", "e", "*T", "M", []int{3}}, "github.com/fcavani/e.(*T).M (closure 3)"},
+		{"github.com/fcavani/e/safe.New[go.shape.string]", Symbol{"github.com/fcavani/e/safe", "safe", "", "New", nil}, "github.com/fcavani/e/safe.New"},
+		{"gopkg.in/vmihailenco/msgpack%2ev2.Marshal", Symbol{"gopkg.in/vmihailenco/msgpack.v2", "msgpack", "", "Marshal", nil}, "gopkg.in/vmihailenco/msgpack.v2.Marshal"},
+		{"github.com/user/repo/v2.Do.gowrap1", Symbol{"github.com/user/repo/v2", "repo", "", "Do", []int{1}}, "github.com/user/repo/v2.Do (closure 1)"},
+		{"github.com/fcavani/e.init.0", Symbol{"github.com/fcavani/e", "e", "", "init.0", nil}, "github.com/fcavani/e.init.0"},
+		{"github.com/fcavani/e.glob..func1", Symbol{"github.com/fcavani/e", "e", "", "glob", []int{1}}, "github.com/fcavani/e.glob (closure 1)"},
+		{"main.main", Symbol{"main", "main", "", "main", nil}, "main.main"},
+		{"", Symbol{}, ""},
+	} {
+		s := ParseSymbol(test.name)
+		if !reflect.DeepEqual(s, test.symbol) {
+			t.Fatalf("wrong symbol of %v: %#v", test.name, s)
+		}
+		if s.String() != test.str {
+			t.Fatal("wrong string", s.String())
+		}
+	}
+}
+
+type symbolTest struct{}
+
+func (s *symbolTest) fail() *Error {
+	return func() *Error {
+		return New(ErrDummy).(*Error)
+	}()
+}
+
+func TestErrorSymbol(t *testing.T) {
+	err := new(symbolTest).fail()
+	if err.ImportPath() != "github.com/fcavani/e" || err.Package() != "e" || err.Receiver() != "*symbolTest" ||
+		err.Function() != "fail" || !reflect.DeepEqual(err.Closure(), []int{1}) {
+		t.Fatal("wrong symbol", err.Pkg())
+	}
+	if !strings.Contains(err.trace("", "", (*Error).formatStack), "\tgithub.com/fcavani/e.(*symbolTest).fail (closure 1)\n") {
+		t.Fatal("wrong trace", err.trace("", "", (*Error).formatStack))
+	}
+	if derr := gobRoundTrip(t, err); derr.Receiver() != "*symbolTest" || derr.Function() != "fail" {
+		t.Fatal("wrong symbol after gob", derr.Pkg())
+	}
+	b, er := json.Marshal(err)
+	if er != nil {
+		t.Fatal(er)
+	}
+	var je jsonError
+	if er := json.Unmarshal(b, &je); er != nil {
+		t.Fatal(er)
+	}
+	if je.Import != "github.com/fcavani/e" || je.Package != "e" || je.Receiver != "*symbolTest" ||
+		je.Function != "fail" || !reflect.DeepEqual(je.Closure, []int{1}) {
+		t.Fatalf("wrong json: %s", b)
+	}
+	if New(ErrDummy).(*Error).Receiver() != "" {
+		t.Fatal("function with receiver")
+	}
+}
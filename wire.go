@@ -0,0 +1,687 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/vmihailenco/msgpack.v2"
+)
+
+// WireVersion is the version of the format written by the gob and msgpack
+// codecs. The decoders read this version and all the versions before it.
+//
+// Version 0 is the format without version of the previous releases.
+//
+// Version 1 starts with Versioned and the version number. Each error of the
+// chain follows as the type of the wrapped error and the error (ErrorGo and
+// a GoError, ErrorRegistered and a value of a registered type, ErrorLocal
+// and a chain or ErrorMulti, the number of merged errors and the chains),
+// the arguments as values of registered types, the debug flag, the stack
+// frames and Next or NextIsNill.
+//
+// Version 2 adds the kind after the stack frames.
+//
+// Version 3 adds the fields after the kind.
+//
+// Version 4 adds the class after the fields.
+//
+// Version 5 adds after the class the time when the error was created, in
+// nanoseconds since the Unix epoch or zero, the monotonic clock, in
+// nanoseconds, and the goroutine ID.
+const WireVersion = 5
+
+// Limits bounds the errors accepted by the gob and msgpack decoders, so
+// payloads from peers that aren't trusted can be decoded. A zero field is
+// no limit.
+type Limits struct {
+	// Depth is the maximum number of errors: the errors in the chain, the
+	// merged errors and the wrapped errors.
+	Depth int
+	// Size is the maximum size in bytes of the payload. For msgpack, that
+	// reads from a stream, it is the size of the strings and values read.
+	Size int
+	// Args is the maximum number of arguments and of fields of each error.
+	Args int
+	// String is the maximum length of each string and each value.
+	String int
+}
+
+var defaultLimits = Limits{
+	Depth:  1024,
+	Size:   1 << 20,
+	Args:   64,
+	String: 64 << 10,
+}
+
+var decodeLimits atomic.Value
+
+// SetDecodeLimits sets the limits used by the decoders.
+func SetDecodeLimits(l Limits) {
+	decodeLimits.Store(l)
+}
+
+// DecodeLimits returns the limits used by the decoders. By default the
+// limits are 1024 errors, 1 MiB, 64 arguments and strings of 64 KiB.
+func DecodeLimits() Limits {
+	l, ok := decodeLimits.Load().(Limits)
+	if !ok {
+		return defaultLimits
+	}
+	return l
+}
+
+// DecodeError is returned by the decoders when the payload exceeds one of
+// the limits.
+type DecodeError struct {
+	// Limit is the name of the limit exceeded: depth, size, args or string.
+	Limit string
+	// Max is the value of the limit.
+	Max int
+}
+
+func (d *DecodeError) Error() string {
+	return "decode: " + d.Limit + " exceeds the limit of " + strconv.Itoa(d.Max)
+}
+
+// encodeFunc and decodeFunc are the Encode and Decode methods of the gob or
+// msgpack encoder and decoder.
+type encodeFunc func(v interface{}) error
+type decodeFunc func(v interface{}) error
+
+// GobEncode implements custom gob encode.
+func (e *Error) GobEncode() ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+	enc := gob.NewEncoder(buf)
+	err := e.encode(enc.Encode)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements custom gob decode.
+func (e *Error) GobDecode(data []byte) error {
+	limits := DecodeLimits()
+	if limits.Size > 0 && len(data) > limits.Size {
+		return &DecodeError{Limit: "size", Max: limits.Size}
+	}
+	r := &reader{limits: limits, errors: 1}
+	return r.readGob(e, data)
+}
+
+// EncodeMsgpack custom msgpack encode function
+func (e *Error) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return e.encode(func(v interface{}) error {
+		return enc.Encode(v)
+	})
+}
+
+// DecodeMsgpack is a custom msgpack decode function.
+func (e *Error) DecodeMsgpack(dec *msgpack.Decoder) error {
+	r := &reader{
+		dec: func(v interface{}) (err error) {
+			// msgpack panics with some invalid payloads.
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("protocol error: %v", r)
+				}
+			}()
+			return dec.Decode(v)
+		},
+		limits: DecodeLimits(),
+		errors: 1,
+	}
+	r.nested = func(er *Error) error {
+		return r.read(er)
+	}
+	return r.read(e)
+}
+
+func (e *Error) encode(enc encodeFunc) error {
+	err := enc(Versioned)
+	if err != nil {
+		return err
+	}
+	err = enc(uint8(WireVersion))
+	if err != nil {
+		return err
+	}
+	return e.writeChain(enc)
+}
+
+func (e *Error) writeChain(enc encodeFunc) error {
+	for link := e; link != nil; link = link.next {
+		err := link.writeError(enc)
+		if err != nil {
+			return err
+		}
+		if link.next == nil {
+			return enc(NextIsNill)
+		}
+		err = enc(Next)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Error) writeError(enc encodeFunc) error {
+	msg := e.err
+	var template string
+	switch v := msg.(type) {
+	case *Error, causes:
+	default:
+		template = v.Error()
+	}
+	args, exact, err := encodeArgs(template, e.args)
+	if err != nil {
+		return err
+	}
+	if !exact {
+		// The message is sent instead.
+		msg = GoError(strings.ReplaceAll(e.formatError(), "%", "%%"))
+	}
+	switch v := msg.(type) {
+	case *Error:
+		err = enc(ErrorLocal)
+		if err != nil {
+			return err
+		}
+		err = v.writeChain(enc)
+		if err != nil {
+			return err
+		}
+	case causes:
+		err = enc(ErrorMulti)
+		if err != nil {
+			return err
+		}
+		err = enc(len(v))
+		if err != nil {
+			return err
+		}
+		for _, cause := range v {
+			err = cause.writeChain(enc)
+			if err != nil {
+				return err
+			}
+		}
+	case error:
+		if isRegisteredError(v) {
+			var val value
+			val, err = encodeValue(v)
+			if err != nil {
+				return err
+			}
+			err = enc(ErrorRegistered)
+			if err != nil {
+				return err
+			}
+			err = enc(val)
+			if err != nil {
+				return err
+			}
+			break
+		}
+		err = enc(ErrorGo)
+		if err != nil {
+			return err
+		}
+		err = enc(GoError(v.Error()))
+		if err != nil {
+			return err
+		}
+	default:
+		panic("type not supported")
+	}
+	err = enc(args)
+	if err != nil {
+		return err
+	}
+	err = enc(e.debugInfo)
+	if err != nil {
+		return err
+	}
+	err = enc(e.StackTrace())
+	if err != nil {
+		return err
+	}
+	err = enc(e.kind)
+	if err != nil {
+		return err
+	}
+	fields, err := encodeFields(e.fields)
+	if err != nil {
+		return err
+	}
+	err = enc(fields)
+	if err != nil {
+		return err
+	}
+	err = enc(uint8(e.class))
+	if err != nil {
+		return err
+	}
+	var wall int64
+	if !e.time.IsZero() {
+		wall = e.time.UnixNano()
+	}
+	err = enc(wall)
+	if err != nil {
+		return err
+	}
+	err = enc(int64(e.mono))
+	if err != nil {
+		return err
+	}
+	return enc(e.goroutine)
+}
+
+// reader reads the wire format. It counts the errors and the bytes read to
+// enforce the limits.
+type reader struct {
+	dec decodeFunc
+	// nested reads an *Error encoded inside the version 0 format.
+	nested func(e *Error) error
+	limits Limits
+	// version of the format being read.
+	version uint8
+	errors  int
+	size    int
+}
+
+// rawGob is an *Error encoded by gob inside the version 0 format, it is
+// decoded by the reader.
+type rawGob []byte
+
+func (r *rawGob) GobDecode(data []byte) error {
+	*r = append((*r)[:0], data...)
+	return nil
+}
+
+func (r *reader) readGob(e *Error, data []byte) error {
+	dec := gob.NewDecoder(bytes.NewBuffer(data))
+	nr := *r
+	nr.dec = dec.Decode
+	nr.nested = func(er *Error) error {
+		var raw rawGob
+		err := dec.Decode(&raw)
+		if err != nil {
+			return err
+		}
+		return nr.readGob(er, raw)
+	}
+	err := nr.read(e)
+	r.errors = nr.errors
+	r.size = nr.size
+	return err
+}
+
+// add accounts n bytes read.
+func (r *reader) add(n int) error {
+	r.size += n
+	if r.limits.Size > 0 && r.size > r.limits.Size {
+		return &DecodeError{Limit: "size", Max: r.limits.Size}
+	}
+	return nil
+}
+
+// newError accounts one more error read.
+func (r *reader) newError() (*Error, error) {
+	r.errors++
+	if r.limits.Depth > 0 && r.errors > r.limits.Depth {
+		return nil, &DecodeError{Limit: "depth", Max: r.limits.Depth}
+	}
+	return &Error{}, nil
+}
+
+func (r *reader) checkString(s string) error {
+	if r.limits.String > 0 && len(s) > r.limits.String {
+		return &DecodeError{Limit: "string", Max: r.limits.String}
+	}
+	return r.add(len(s))
+}
+
+// read reads one *Error into e, e is already accounted.
+func (r *reader) read(e *Error) error {
+	*e = Error{}
+	var msg messageType
+	err := r.dec(&msg)
+	if err != nil {
+		return err
+	}
+	if msg != Versioned {
+		return r.readV0(e, msg)
+	}
+	err = r.dec(&r.version)
+	if err != nil {
+		return err
+	}
+	if r.version < 1 || r.version > WireVersion {
+		return errors.New("unsupported version")
+	}
+	return r.readChain(e)
+}
+
+// readChain reads the chain into e, e is already accounted.
+func (r *reader) readChain(e *Error) error {
+	for link := e; ; {
+		err := r.readError(link)
+		if err != nil {
+			return err
+		}
+		var msg messageType
+		err = r.dec(&msg)
+		if err != nil {
+			return err
+		}
+		switch msg {
+		case NextIsNill:
+			return nil
+		case Next:
+			link.next, err = r.newError()
+			if err != nil {
+				return err
+			}
+			link = link.next
+		default:
+			return errors.New("protocol error")
+		}
+	}
+}
+
+func (r *reader) readError(e *Error) error {
+	var msg messageType
+	err := r.dec(&msg)
+	if err != nil {
+		return err
+	}
+	switch msg {
+	case ErrorLocal:
+		er, err := r.newError()
+		if err != nil {
+			return err
+		}
+		err = r.readChain(er)
+		if err != nil {
+			return err
+		}
+		e.err = er
+	case ErrorMulti:
+		var n int
+		err = r.dec(&n)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return errors.New("protocol error")
+		}
+		if r.limits.Depth > 0 && r.errors+n > r.limits.Depth {
+			return &DecodeError{Limit: "depth", Max: r.limits.Depth}
+		}
+		c := make(causes, 0, n)
+		for i := 0; i < n; i++ {
+			cause, err := r.newError()
+			if err != nil {
+				return err
+			}
+			err = r.readChain(cause)
+			if err != nil {
+				return err
+			}
+			c = append(c, cause)
+		}
+		e.err = c
+	case ErrorRegistered:
+		var val value
+		err = r.dec(&val)
+		if err != nil {
+			return err
+		}
+		v, err := r.readValue(val)
+		if err != nil {
+			return err
+		}
+		er, ok := v.(error)
+		if !ok {
+			return errors.New("protocol error")
+		}
+		e.err = er
+	case ErrorGo:
+		var er GoError
+		err = r.dec(&er)
+		if err != nil {
+			return err
+		}
+		err = r.checkString(string(er))
+		if err != nil {
+			return err
+		}
+		e.err = er
+	default:
+		return errors.New("protocol error")
+	}
+	var vals []value
+	err = r.dec(&vals)
+	if err != nil {
+		return err
+	}
+	if r.limits.Args > 0 && len(vals) > r.limits.Args {
+		return &DecodeError{Limit: "args", Max: r.limits.Args}
+	}
+	if len(vals) > 0 {
+		e.args = make([]interface{}, 0, len(vals))
+	}
+	for _, val := range vals {
+		arg, err := r.readValue(val)
+		if err != nil {
+			return err
+		}
+		e.args = append(e.args, arg)
+	}
+	err = r.dec(&e.debugInfo)
+	if err != nil {
+		return err
+	}
+	err = r.dec(&e.frames)
+	if err != nil {
+		return err
+	}
+	for _, f := range e.frames {
+		err = r.checkFrame(f)
+		if err != nil {
+			return err
+		}
+	}
+	if len(e.frames) == 0 {
+		e.frames = nil
+	}
+	if r.version >= 2 {
+		err = r.dec(&e.kind)
+		if err != nil {
+			return err
+		}
+		err = r.checkString(string(e.kind))
+		if err != nil {
+			return err
+		}
+	}
+	if r.version >= 3 {
+		var fields []field
+		err = r.dec(&fields)
+		if err != nil {
+			return err
+		}
+		if r.limits.Args > 0 && len(fields) > r.limits.Args {
+			return &DecodeError{Limit: "args", Max: r.limits.Args}
+		}
+		if len(fields) > 0 {
+			e.fields = make([]Field, 0, len(fields))
+		}
+		for _, f := range fields {
+			err = r.checkString(f.Key)
+			if err != nil {
+				return err
+			}
+			v, err := r.readValue(f.Value)
+			if err != nil {
+				return err
+			}
+			e.fields = append(e.fields, Field{Key: f.Key, Value: v})
+		}
+	}
+	if r.version >= 4 {
+		var class uint8
+		err = r.dec(&class)
+		if err != nil {
+			return err
+		}
+		e.class = Class(class)
+	}
+	if r.version >= 5 {
+		var wall, mono int64
+		err = r.dec(&wall)
+		if err != nil {
+			return err
+		}
+		if wall != 0 {
+			e.time = time.Unix(0, wall)
+		}
+		err = r.dec(&mono)
+		if err != nil {
+			return err
+		}
+		e.mono = time.Duration(mono)
+		err = r.dec(&e.goroutine)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reader) readValue(val value) (interface{}, error) {
+	err := r.checkString(val.Type)
+	if err != nil {
+		return nil, err
+	}
+	if r.limits.String > 0 && len(val.Data) > r.limits.String {
+		return nil, &DecodeError{Limit: "string", Max: r.limits.String}
+	}
+	err = r.add(len(val.Data))
+	if err != nil {
+		return nil, err
+	}
+	return val.decode()
+}
+
+// frameSize is the size accounted for each frame besides its strings.
+const frameSize = 8
+
+func (r *reader) checkFrame(f Frame) error {
+	err := r.checkString(f.Function)
+	if err != nil {
+		return err
+	}
+	err = r.checkString(f.File)
+	if err != nil {
+		return err
+	}
+	return r.add(frameSize)
+}
+
+// readV0 reads the format of the version 0, msg is the first value.
+func (r *reader) readV0(e *Error, msg messageType) error {
+	switch msg {
+	case ErrorLocal:
+		er, err := r.newError()
+		if err != nil {
+			return err
+		}
+		err = r.nested(er)
+		if err != nil {
+			return err
+		}
+		e.err = er
+	case ErrorGo:
+		var er GoError
+		err := r.dec(&er)
+		if err != nil {
+			return err
+		}
+		err = r.checkString(string(er))
+		if err != nil {
+			return err
+		}
+		e.err = er
+	default:
+		return errors.New("protocol error")
+	}
+	err := r.dec(&e.args)
+	if err != nil {
+		return err
+	}
+	if r.limits.Args > 0 && len(e.args) > r.limits.Args {
+		return &DecodeError{Limit: "args", Max: r.limits.Args}
+	}
+	for _, arg := range e.args {
+		if s, ok := arg.(string); ok {
+			err = r.checkString(s)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	var caller Frame
+	err = r.dec(&caller.Function)
+	if err != nil {
+		return err
+	}
+	err = r.dec(&caller.File)
+	if err != nil {
+		return err
+	}
+	err = r.dec(&caller.Line)
+	if err != nil {
+		return err
+	}
+	err = r.checkFrame(caller)
+	if err != nil {
+		return err
+	}
+	err = r.dec(&e.debugInfo)
+	if err != nil {
+		return err
+	}
+	if e.debugInfo {
+		e.frames = []Frame{caller}
+	}
+	err = r.dec(&msg)
+	if err != nil {
+		return err
+	}
+	switch msg {
+	case NextIsNill:
+		return nil
+	case Next:
+		e.next, err = r.newError()
+		if err != nil {
+			return err
+		}
+		return r.nested(e.next)
+	default:
+		return errors.New("protocol error")
+	}
+}
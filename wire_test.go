@@ -0,0 +1,337 @@
+// Copyright 2026 Felipe A. Cavani. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+// Start date:		2026-10-18
+
+package e
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"gopkg.in/vmihailenco/msgpack.v2"
+)
+
+// The golden files in testdata are payloads written by the codecs, one for
+// each version of the wire format. They must never change: when the format
+// changes WireVersion is incremented and the files of the new version are
+// added.
+var update = flag.Bool("update", false, "write the golden files of the current version")
+
+const traceV0 = `github.com/fcavani/e.TestGenerate - e/golden_test.go - 18: no debug
+no debug
+github.com/fcavani/e.TestGenerate - e/golden_test.go - 14: string error
+github.com/fcavani/e.TestGenerate - e/golden_test.go - 13: value 42 foo
+`
+
+func readGolden(t *testing.T, name string) []byte {
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func decodeGob(data []byte) (*Error, error) {
+	var err *Error
+	return err, gob.NewDecoder(bytes.NewReader(data)).Decode(&err)
+}
+
+func decodeMsgpack(data []byte) (*Error, error) {
+	var err *Error
+	return err, msgpack.Unmarshal(data, &err)
+}
+
+func TestGoldenV0(t *testing.T) {
+	for name, decode := range map[string]func([]byte) (*Error, error){"v0.gob": decodeGob, "v0.msgpack": decodeMsgpack} {
+		err, er := decode(readGolden(t, name))
+		if er != nil {
+			t.Fatal(name, er)
+		}
+		if err.Trace() != traceV0 {
+			t.Fatalf("%v: wrong trace:\n%v", name, err.Trace())
+		}
+		if err.Find("value %d %v") != 3 {
+			t.Fatal(name, "Find failed")
+		}
+		if err.Debug() != true || err.next.Debug() != false {
+			t.Fatal(name, "wrong debug info")
+		}
+		if len(err.StackTrace()) != 1 || err.StackTrace()[0].Line != 18 {
+			t.Fatal(name, "wrong stack")
+		}
+	}
+}
+
+func goldenFrames(line int) []Frame {
+	return []Frame{
+		{Function: "github.com/fcavani/e.golden", File: "/src/e/golden.go", Line: line},
+		{Function: "main.main", File: "/src/main.go", Line: 3},
+	}
+}
+
+// goldenError returns an error with all the features of the version of
+// the wire format.
+func goldenError(version int) *Error {
+	base := &Error{
+		err:       GoError("value %d %v %v %v"),
+		args:      []interface{}{42, "foo", customArg{Name: "bar", Count: 1}, nil},
+		debugInfo: true,
+		frames:    goldenFrames(10),
+	}
+	registered := &Error{
+		err:       &customError{Code: 7, Msg: "custom"},
+		debugInfo: true,
+		frames:    goldenFrames(11),
+		next:      base,
+	}
+	noDebug := &Error{
+		err:  GoError("no debug"),
+		next: registered,
+	}
+	merged := &Error{
+		err:       causes{noDebug, &Error{err: syscall.ENOENT, debugInfo: true, frames: goldenFrames(12)}},
+		debugInfo: true,
+		frames:    goldenFrames(13),
+	}
+	top := &Error{
+		err:       &Error{err: GoError("local"), debugInfo: true, frames: goldenFrames(14)},
+		debugInfo: true,
+		frames:    goldenFrames(15),
+		next:      merged,
+	}
+	if version >= 2 {
+		base.kind = ErrInvalidType
+		noDebug.err = ErrEmptyString
+		noDebug.kind = ErrEmptyString
+		top.kind = Kind("golden")
+	}
+	if version >= 3 {
+		base.fields = []Field{{Key: "id", Value: 42}, {Key: "arg", Value: customArg{Name: "baz", Count: 2}}}
+		top.fields = []Field{{Key: "user", Value: "foo"}, {Key: "none", Value: nil}}
+	}
+	if version >= 4 {
+		base.class = Permanent
+		top.class = Retryable
+	}
+	if version >= 5 {
+		base.time = time.Unix(0, 1700000000123456789)
+		base.mono = 1500 * time.Millisecond
+		base.goroutine = 7
+		top.time = time.Unix(0, 1700000000135456789)
+		top.mono = 1512 * time.Millisecond
+		top.goroutine = 8
+	}
+	return top
+}
+
+func encodeGob(err *Error) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+	er := gob.NewEncoder(buf).Encode(err)
+	return buf.Bytes(), er
+}
+
+func encodeMsgpack(err *Error) ([]byte, error) {
+	return msgpack.Marshal(err)
+}
+
+func TestGolden(t *testing.T) {
+	codecs := map[string]struct {
+		encode func(*Error) ([]byte, error)
+		decode func([]byte) (*Error, error)
+	}{
+		"gob":     {encodeGob, decodeGob},
+		"msgpack": {encodeMsgpack, decodeMsgpack},
+	}
+	for version := 1; version <= WireVersion; version++ {
+		golden := goldenError(version)
+		for ext, codec := range codecs {
+			name := "v" + strconv.Itoa(version) + "." + ext
+			if *update && version == WireVersion {
+				b, er := codec.encode(golden)
+				if er != nil {
+					t.Fatal(name, er)
+				}
+				er = os.WriteFile(filepath.Join("testdata", name), b, 0644)
+				if er != nil {
+					t.Fatal(name, er)
+				}
+			}
+			data := readGolden(t, name)
+			err, er := codec.decode(data)
+			if er != nil {
+				t.Fatal(name, er)
+			}
+			if !reflect.DeepEqual(err, golden) {
+				t.Fatalf("%v: wrong error:\n%v\n%v", name, err.Trace(), golden.Trace())
+			}
+			b, er := codec.encode(err)
+			if er != nil {
+				t.Fatal(name, er)
+			}
+			err, er = codec.decode(b)
+			if er != nil {
+				t.Fatal(name, er)
+			}
+			if !reflect.DeepEqual(err, golden) {
+				t.Fatalf("%v: wrong error after encode:\n%v", name, err.Trace())
+			}
+		}
+	}
+}
+
+func TestUnsupportedVersion(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	enc := gob.NewEncoder(buf)
+	enc.Encode(Versioned)
+	enc.Encode(uint8(WireVersion + 1))
+	var err Error
+	if er := err.GobDecode(buf.Bytes()); er == nil || er.Error() != "unsupported version" {
+		t.Fatal("version accepted", er)
+	}
+}
+
+func withLimits(t *testing.T, l Limits) {
+	old := DecodeLimits()
+	SetDecodeLimits(l)
+	t.Cleanup(func() {
+		SetDecodeLimits(old)
+	})
+}
+
+func checkLimit(t *testing.T, name string, er error, limit string) {
+	var de *DecodeError
+	if !errors.As(er, &de) {
+		t.Fatalf("%v: not a DecodeError: %v", name, er)
+	}
+	if de.Limit != limit {
+		t.Fatalf("%v: wrong limit: %v", name, de)
+	}
+}
+
+func TestDecodeLimits(t *testing.T) {
+	long := New(ErrDummy).(*Error)
+	for i := 0; i < 20; i++ {
+		long = long.Push(ErrStr)
+	}
+	merged := Merge(long, ErrSilly)
+	many := New("%v%v%v%v%v", 1, 2, 3, 4, 5).(*Error)
+	big := New(strings.Repeat("x", 100)).(*Error)
+	bigArg := New("%v", strings.Repeat("x", 100)).(*Error)
+	tests := []struct {
+		err    error
+		limits Limits
+		limit  string
+	}{
+		{long, Limits{Depth: 20}, "depth"},
+		{merged, Limits{Depth: 21}, "depth"},
+		{Push(New(ErrDummy), Merge(long, long)), Limits{Depth: 30}, "depth"},
+		{many, Limits{Args: 4}, "args"},
+		{big, Limits{String: 99}, "string"},
+		{bigArg, Limits{String: 50}, "string"},
+		{long, Limits{Size: 500}, "size"},
+	}
+	for i, test := range tests {
+		name := strconv.Itoa(i)
+		g, er := encodeGob(test.err.(*Error))
+		if er != nil {
+			t.Fatal(name, er)
+		}
+		m, er := encodeMsgpack(test.err.(*Error))
+		if er != nil {
+			t.Fatal(name, er)
+		}
+		withLimits(t, test.limits)
+		_, er = decodeGob(g)
+		checkLimit(t, name+" gob", er, test.limit)
+		_, er = decodeMsgpack(m)
+		checkLimit(t, name+" msgpack", er, test.limit)
+		withLimits(t, Limits{})
+		if _, er = decodeGob(g); er != nil {
+			t.Fatal(name, er)
+		}
+		if _, er = decodeMsgpack(m); er != nil {
+			t.Fatal(name, er)
+		}
+	}
+}
+
+func TestDecodeLimitsV0(t *testing.T) {
+	withLimits(t, Limits{Depth: 3})
+	for name, decode := range map[string]func([]byte) (*Error, error){"v0.gob": decodeGob, "v0.msgpack": decodeMsgpack} {
+		_, er := decode(readGolden(t, name))
+		checkLimit(t, name, er, "depth")
+	}
+	withLimits(t, Limits{Depth: 4})
+	for name, decode := range map[string]func([]byte) (*Error, error){"v0.gob": decodeGob, "v0.msgpack": decodeMsgpack} {
+		if _, er := decode(readGolden(t, name)); er != nil {
+			t.Fatal(name, er)
+		}
+	}
+}
+
+func TestDecodeMergeCount(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	enc := gob.NewEncoder(buf)
+	enc.Encode(Versioned)
+	enc.Encode(uint8(WireVersion))
+	enc.Encode(ErrorMulti)
+	enc.Encode(1 << 40)
+	var err Error
+	checkLimit(t, "gob", err.GobDecode(buf.Bytes()), "depth")
+}
+
+func fuzzSeeds(f *testing.F, encode func(*Error) ([]byte, error), ext string) {
+	for version := 0; version <= WireVersion; version++ {
+		b, err := os.ReadFile(filepath.Join("testdata", "v"+strconv.Itoa(version)+"."+ext))
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+	for _, err := range []*Error{goldenError(WireVersion), New(ErrDummy).(*Error).Push(ErrStr)} {
+		b, er := encode(err)
+		if er != nil {
+			f.Fatal(er)
+		}
+		f.Add(b)
+	}
+}
+
+func FuzzGobDecode(f *testing.F) {
+	fuzzSeeds(f, encodeGob, "gob")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var err Error
+		if err.GobDecode(data) != nil {
+			return
+		}
+		_ = err.Trace()
+		if _, er := encodeGob(&err); er != nil {
+			t.Fatal(er)
+		}
+	})
+}
+
+func FuzzDecodeMsgpack(f *testing.F) {
+	fuzzSeeds(f, encodeMsgpack, "msgpack")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var err *Error
+		if msgpack.Unmarshal(data, &err) != nil || err == nil {
+			return
+		}
+		_ = err.Trace()
+		if _, er := encodeMsgpack(err); er != nil {
+			t.Fatal(er)
+		}
+	})
+}